@@ -1,6 +1,7 @@
 package junyousdk
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -36,7 +37,12 @@ type EnterpriseJKSURLRequest struct {
 
 // Register 注册
 func (s *APIService) Register(registerInfo *RegisterInfo) (*Result[string], error) {
-	return DoRequest[string](s.client,
+	return s.RegisterContext(context.Background(), registerInfo)
+}
+
+// RegisterContext 同 Register，ctx 用于取消与超时控制
+func (s *APIService) RegisterContext(ctx context.Context, registerInfo *RegisterInfo) (*Result[string], error) {
+	return DoRequestContext[string](ctx, s.client,
 		http.MethodPost,
 		APIPathRegister,
 		registerInfo,
@@ -46,7 +52,12 @@ func (s *APIService) Register(registerInfo *RegisterInfo) (*Result[string], erro
 
 // AuthLogin 登录认证
 func (s *APIService) AuthLogin(openIdToken OpenIdToken) (*Result[string], error) {
-	return DoRequest[string](s.client,
+	return s.AuthLoginContext(context.Background(), openIdToken)
+}
+
+// AuthLoginContext 同 AuthLogin，ctx 用于取消与超时控制
+func (s *APIService) AuthLoginContext(ctx context.Context, openIdToken OpenIdToken) (*Result[string], error) {
+	return DoRequestContext[string](ctx, s.client,
 		http.MethodPost,
 		APIPathAuthLogin,
 		openIdToken,
@@ -56,7 +67,12 @@ func (s *APIService) AuthLogin(openIdToken OpenIdToken) (*Result[string], error)
 
 // AuthSetPWD 设置密码认证
 func (s *APIService) AuthSetPWD(openIdToken OpenIdToken) (*Result[string], error) {
-	return DoRequest[string](s.client,
+	return s.AuthSetPWDContext(context.Background(), openIdToken)
+}
+
+// AuthSetPWDContext 同 AuthSetPWD，ctx 用于取消与超时控制
+func (s *APIService) AuthSetPWDContext(ctx context.Context, openIdToken OpenIdToken) (*Result[string], error) {
+	return DoRequestContext[string](ctx, s.client,
 		http.MethodPost,
 		APIPathAuthSetPWD,
 		openIdToken,
@@ -66,7 +82,12 @@ func (s *APIService) AuthSetPWD(openIdToken OpenIdToken) (*Result[string], error
 
 // AuthCMT 验证认证
 func (s *APIService) AuthCMT(openIdToken OpenIdToken) (*Result[string], error) {
-	return DoRequest[string](s.client,
+	return s.AuthCMTContext(context.Background(), openIdToken)
+}
+
+// AuthCMTContext 同 AuthCMT，ctx 用于取消与超时控制
+func (s *APIService) AuthCMTContext(ctx context.Context, openIdToken OpenIdToken) (*Result[string], error) {
+	return DoRequestContext[string](ctx, s.client,
 		http.MethodPost,
 		APIPathAuthCMT,
 		openIdToken,
@@ -76,7 +97,12 @@ func (s *APIService) AuthCMT(openIdToken OpenIdToken) (*Result[string], error) {
 
 // SetEnterpriseJKSURL 设置企业 JKS 地址
 func (s *APIService) SetEnterpriseJKSURL(req EnterpriseJKSURLRequest) (*Result[map[string]any], error) {
-	return DoRequest[map[string]any](s.client,
+	return s.SetEnterpriseJKSURLContext(context.Background(), req)
+}
+
+// SetEnterpriseJKSURLContext 同 SetEnterpriseJKSURL，ctx 用于取消与超时控制
+func (s *APIService) SetEnterpriseJKSURLContext(ctx context.Context, req EnterpriseJKSURLRequest) (*Result[map[string]any], error) {
+	return DoRequestContext[map[string]any](ctx, s.client,
 		http.MethodPost,
 		APIPathEnterpriseJKSURL,
 		req,
@@ -1,12 +1,12 @@
 package junyousdk
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/junyouava/junyou-sdk-go/internal"
 )
@@ -56,14 +56,31 @@ func (a *AuthService) GenerateSignature(method, apiPath string) (*Signature, err
 	}
 
 	// 生成 nonce
-	nonce, err := internal.GenerateNonce(4)
+	nonce, err := config.NonceGenerator.Nonce()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	// 生成时间戳（当前时间加 3 分钟）
-	timestamp := strconv.FormatInt(time.Now().Add(3*time.Minute).Unix(), 10)
+	// 生成时间戳（当前时间加签名有效期）
+	timestamp := strconv.FormatInt(config.Clock.Now().Add(config.SignatureValidity).Unix(), 10)
 
+	// 计算签名
+	signature, err := computeSignature(config.AccessKey, config.AccessId, method, apiPath, nonce, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signature{
+		AccessId:  config.AccessId,
+		Signature: signature,
+		Nonce:     nonce,
+		Timestamp: timestamp,
+	}, nil
+}
+
+// computeSignature 计算签名：对 accessId\nMETHOD\npath\nnonce\ntimestamp 做 HMAC-SHA256 后 Base64 编码。
+// 客户端签名与 Verifier 验签共用。
+func computeSignature(accessKey, accessId, method, apiPath, nonce, timestamp string) (string, error) {
 	// 规范化 HTTP 方法为大写，避免调用方传小写导致验签失败
 	methodUpper := strings.ToUpper(method)
 
@@ -74,24 +91,17 @@ func (a *AuthService) GenerateSignature(method, apiPath string) (*Signature, err
 	}
 
 	// 构建签名字符串（包含 accessId 作为第一个字段）
-	signString := fmt.Sprintf("%s\n%s\n%s\n%s\n%s", config.AccessId, methodUpper, pathForSign, nonce, timestamp)
+	signString := fmt.Sprintf("%s\n%s\n%s\n%s\n%s", accessId, methodUpper, pathForSign, nonce, timestamp)
 
 	// 解码 AccessKey (Base64)
-	accessKeyBytes, err := base64.StdEncoding.DecodeString(config.AccessKey)
+	accessKeyBytes, err := base64.StdEncoding.DecodeString(accessKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode access_key: %w", err)
+		return "", fmt.Errorf("failed to decode access_key: %w", err)
 	}
 
 	// 计算 HMAC-SHA256 签名
 	signatureBytes := internal.HMACSHA256(accessKeyBytes, []byte(signString))
-	signature := base64.StdEncoding.EncodeToString(signatureBytes)
-
-	return &Signature{
-		AccessId:  config.AccessId,
-		Signature: signature,
-		Nonce:     nonce,
-		Timestamp: timestamp,
-	}, nil
+	return base64.StdEncoding.EncodeToString(signatureBytes), nil
 }
 
 // GenerateAuthHeader 生成认证 Header
@@ -117,6 +127,11 @@ func (a *AuthService) GenerateAuthHeader(method, apiPath string) (http.Header, e
 
 // GenerateSignatureWithOpenAuth 生成签名并调用 AuthCMT，合并返回签名信息和OpenAuth
 func (a *AuthService) GenerateSignatureWithOpenAuth(method, apiPath string, openIdToken OpenIdToken) (*SignatureWithOpenAuth, error) {
+	return a.GenerateSignatureWithOpenAuthContext(context.Background(), method, apiPath, openIdToken)
+}
+
+// GenerateSignatureWithOpenAuthContext 同 GenerateSignatureWithOpenAuth，ctx 用于 AuthCMT 调用的取消与超时控制
+func (a *AuthService) GenerateSignatureWithOpenAuthContext(ctx context.Context, method, apiPath string, openIdToken OpenIdToken) (*SignatureWithOpenAuth, error) {
 	// 生成签名
 	signature, err := a.GenerateSignature(method, apiPath)
 	if err != nil {
@@ -124,7 +139,7 @@ func (a *AuthService) GenerateSignatureWithOpenAuth(method, apiPath string, open
 	}
 
 	// 调用 AuthCMT
-	result, err := a.client.API().AuthCMT(openIdToken)
+	result, err := a.client.API().AuthCMTContext(ctx, openIdToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call AuthCMT: %w", err)
 	}
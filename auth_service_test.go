@@ -0,0 +1,90 @@
+package junyousdk_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+)
+
+func TestGenerateSignatureDeterministic(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId(testAccessId).
+		WithAccessKey(testAccessKey).
+		WithClock(junyousdk.ClockFunc(func() time.Time { return now })).
+		WithNonceGenerator(junyousdk.NonceGeneratorFunc(func() (string, error) { return "fixednonce000001", nil })).
+		WithSignatureValidity(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := client.Auth().GenerateSignature("post", "/api/open/v1/register?x=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.Nonce != "fixednonce000001" || sig.Timestamp != strconv.FormatInt(now.Add(time.Minute).Unix(), 10) {
+		t.Fatalf("nonce = %s, timestamp = %s", sig.Nonce, sig.Timestamp)
+	}
+
+	key, _ := base64.StdEncoding.DecodeString(testAccessKey)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(testAccessId + "\nPOST\n/api/open/v1/register\nfixednonce000001\n" + sig.Timestamp))
+	if want := base64.StdEncoding.EncodeToString(mac.Sum(nil)); sig.Signature != want {
+		t.Fatalf("signature = %s, want %s", sig.Signature, want)
+	}
+
+	header, err := client.Auth().GenerateAuthHeader(http.MethodPost, "/api/open/v1/register")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header[junyousdk.HeaderSignature][0] != sig.Signature || header[junyousdk.HeaderAccessId][0] != testAccessId {
+		t.Fatalf("header = %v", header)
+	}
+}
+
+func TestDefaultNonceGenerator(t *testing.T) {
+	gen, err := junyousdk.NewNonceGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern := regexp.MustCompile(`^[0-9A-Za-z]{16}$`)
+	seen := map[string]bool{}
+	for i := 0; i < 10000; i++ {
+		nonce, err := gen.Nonce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pattern.MatchString(nonce) {
+			t.Fatalf("nonce %q is not 16 alphanumeric characters", nonce)
+		}
+		if seen[nonce] {
+			t.Fatalf("duplicate nonce %q after %d calls", nonce, i)
+		}
+		seen[nonce] = true
+	}
+}
+
+func TestDefaultSignatureValidity(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId(testAccessId).
+		WithAccessKey(testAccessKey).
+		WithClock(junyousdk.ClockFunc(func() time.Time { return now })))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := client.Auth().GenerateSignature(http.MethodGet, "/x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strconv.FormatInt(now.Add(junyousdk.DefaultSignatureValidity).Unix(), 10); sig.Timestamp != want {
+		t.Fatalf("timestamp = %s, want %s", sig.Timestamp, want)
+	}
+}
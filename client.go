@@ -3,6 +3,7 @@ package junyousdk
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -12,10 +13,14 @@ type Client struct {
 	httpClient *http.Client
 	auth       *AuthService
 	api        *APIService
+	tokens     *TokenManager
+
+	mu           sync.RWMutex
+	interceptors []Interceptor
 }
 
 // applyDefaultConfig 应用默认配置值
-func applyDefaultConfig(config *Config) {
+func applyDefaultConfig(config *Config) error {
 	if config.Address == "" {
 		config.Address = DefaultAddress
 	}
@@ -25,6 +30,20 @@ func applyDefaultConfig(config *Config) {
 	if config.ContentType == "" {
 		config.ContentType = DefaultContentType
 	}
+	if config.Clock == nil {
+		config.Clock = SystemClock
+	}
+	if config.SignatureValidity <= 0 {
+		config.SignatureValidity = DefaultSignatureValidity
+	}
+	if config.NonceGenerator == nil {
+		generator, err := NewNonceGenerator()
+		if err != nil {
+			return err
+		}
+		config.NonceGenerator = generator
+	}
+	return nil
 }
 
 // validateConfig 验证配置
@@ -50,7 +69,9 @@ func NewClient(config *Config) (*Client, error) {
 	if config == nil {
 		config = DefaultConfig()
 	}
-	applyDefaultConfig(config)
+	if err := applyDefaultConfig(config); err != nil {
+		return nil, err
+	}
 
 	if err := validateConfig(config); err != nil {
 		return nil, err
@@ -64,6 +85,7 @@ func NewClient(config *Config) (*Client, error) {
 	// 初始化服务
 	client.auth = NewAuthService(client)
 	client.api = NewAPIService(client)
+	client.tokens = NewTokenManager(client, config.TokenTTL)
 
 	return client, nil
 }
@@ -73,7 +95,9 @@ func NewClientWithHTTPClient(config *Config, httpClient *http.Client) (*Client,
 	if config == nil {
 		config = DefaultConfig()
 	}
-	applyDefaultConfig(config)
+	if err := applyDefaultConfig(config); err != nil {
+		return nil, err
+	}
 
 	if err := validateConfig(config); err != nil {
 		return nil, err
@@ -92,6 +116,7 @@ func NewClientWithHTTPClient(config *Config, httpClient *http.Client) (*Client,
 	// 初始化服务
 	client.auth = NewAuthService(client)
 	client.api = NewAPIService(client)
+	client.tokens = NewTokenManager(client, config.TokenTTL)
 
 	return client, nil
 }
@@ -115,3 +140,8 @@ func (c *Client) Auth() *AuthService {
 func (c *Client) API() *APIService {
 	return c.api
 }
+
+// Tokens 返回 Open Token 管理器
+func (c *Client) Tokens() *TokenManager {
+	return c.tokens
+}
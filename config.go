@@ -1,5 +1,11 @@
 package junyousdk
 
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
 // Config SDK 配置结构
 type Config struct {
 	// AccessId 访问 ID
@@ -12,6 +18,44 @@ type Config struct {
 	Address string
 	// ContentType 请求内容类型（可选，默认 application/json）
 	ContentType string
+	// RetryPolicy 重试策略（可选，nil 表示不重试）
+	RetryPolicy *RetryPolicy
+	// Logger 日志记录器（可选，nil 表示不记录日志）。签名、X-Open-Auth、der_hex、手机号等敏感信息会被脱敏
+	Logger *slog.Logger
+	// LogLevel 成功请求的日志级别（可选，默认 Info）
+	LogLevel slog.Leveler
+	// ErrorLogLevel 失败请求的日志级别（可选，默认 Warn）
+	ErrorLogLevel slog.Leveler
+	// Clock 时钟（可选，默认 SystemClock），用于生成签名时间戳
+	Clock Clock
+	// NonceGenerator 签名 nonce 生成器（可选，默认 NewNonceGenerator 创建的 16 位唯一 nonce）
+	NonceGenerator NonceGenerator
+	// SignatureValidity 签名有效期（可选，默认 DefaultSignatureValidity），X-Timestamp 为当前时间加该时长
+	SignatureValidity time.Duration
+	// TokenTTL Client.Tokens 缓存 Open Token 的时长（可选，默认 DefaultTokenTTL），仅用于只读查询
+	TokenTTL time.Duration
+	// AutoRelogin 为 true 时，...ByOpenId 方法遇到 Token 被拒绝（ErrOpenAuthInvalid、ErrMissingUserIdentity）会重新登录并重放一次请求
+	AutoRelogin bool
+	// OnRelogin 自动重新登录时的回调（可选），用于记录日志或指标
+	OnRelogin func(ctx context.Context, event ReloginEvent)
+	// Journal 两阶段操作日志（可选，nil 表示不记录）。RewardGOCFlow、ReleaseEWTByPartner 记录各阶段，Resume 据此恢复未完成的操作
+	Journal Journal
+	// IdempotencyStore 幂等键存储（可选）。RewardGOCOnce、ReleaseEWTByPartnerOnce 据此对同一业务键只发放一次
+	IdempotencyStore IdempotencyStore
+	// EnterpriseAddress 企业出账链上地址（可选），VerifyPreReward、VerifyPreEWTRelease 据此校验预提交消息的 from
+	EnterpriseAddress string
+	// GOCRewardBizType GOC 预提交消息应有的 biz_type（可选），VerifyPreReward 据此校验
+	GOCRewardBizType string
+	// EWTReleaseBizType 权证合伙人释放预提交消息应有的 biz_type（可选），VerifyPreEWTRelease 据此校验
+	EWTReleaseBizType string
+	// AddressResolver 按 OpenId 查询用户链上地址（可选）。开启 VerifyPreSubmit 时，流程方法据此得到预提交消息应有的 to
+	AddressResolver func(ctx context.Context, openId string) (string, error)
+	// VerifyPreSubmit 为 true 时，RewardGOCFlow、ReleaseEWTByPartner（及 ...Once）签名前以 VerifyPreReward、VerifyPreEWTRelease
+	// 校验预提交消息，此时 EnterpriseAddress、对应的 biz_type 与 AddressResolver 均须设置；默认关闭，流程不做校验
+	VerifyPreSubmit bool
+	// VerifyCommitSignature 为 true 时，RewardGOC、CommitEWTReleaseByPartner 及其变体发送前用 VerifyChainSignature 校验签名，
+	// 校验失败时不发送请求
+	VerifyCommitSignature bool
 }
 
 // DefaultConfig 返回默认配置
@@ -52,3 +96,101 @@ func (c *Config) WithContentType(contentType string) *Config {
 	c.ContentType = contentType
 	return c
 }
+
+// WithRetryPolicy 设置重试策略
+func (c *Config) WithRetryPolicy(policy *RetryPolicy) *Config {
+	c.RetryPolicy = policy
+	return c
+}
+
+// WithLogger 设置日志记录器
+func (c *Config) WithLogger(logger *slog.Logger) *Config {
+	c.Logger = logger
+	return c
+}
+
+// WithLogLevel 设置成功请求与失败请求的日志级别
+func (c *Config) WithLogLevel(level, errorLevel slog.Leveler) *Config {
+	c.LogLevel = level
+	c.ErrorLogLevel = errorLevel
+	return c
+}
+
+// WithClock 设置时钟
+func (c *Config) WithClock(clock Clock) *Config {
+	c.Clock = clock
+	return c
+}
+
+// WithNonceGenerator 设置 nonce 生成器
+func (c *Config) WithNonceGenerator(generator NonceGenerator) *Config {
+	c.NonceGenerator = generator
+	return c
+}
+
+// WithSignatureValidity 设置签名有效期
+func (c *Config) WithSignatureValidity(validity time.Duration) *Config {
+	c.SignatureValidity = validity
+	return c
+}
+
+// WithTokenTTL 设置 Open Token 缓存时长
+func (c *Config) WithTokenTTL(ttl time.Duration) *Config {
+	c.TokenTTL = ttl
+	return c
+}
+
+// WithAutoRelogin 开启或关闭 Token 被拒绝时的自动重新登录
+func (c *Config) WithAutoRelogin(enabled bool) *Config {
+	c.AutoRelogin = enabled
+	return c
+}
+
+// WithOnRelogin 设置自动重新登录回调
+func (c *Config) WithOnRelogin(hook func(ctx context.Context, event ReloginEvent)) *Config {
+	c.OnRelogin = hook
+	return c
+}
+
+// WithJournal 设置两阶段操作日志
+func (c *Config) WithJournal(journal Journal) *Config {
+	c.Journal = journal
+	return c
+}
+
+// WithIdempotencyStore 设置幂等键存储
+func (c *Config) WithIdempotencyStore(store IdempotencyStore) *Config {
+	c.IdempotencyStore = store
+	return c
+}
+
+// WithEnterpriseAddress 设置企业出账链上地址
+func (c *Config) WithEnterpriseAddress(address string) *Config {
+	c.EnterpriseAddress = address
+	return c
+}
+
+// WithBizTypes 设置 GOC 奖励与权证合伙人释放预提交消息应有的 biz_type
+func (c *Config) WithBizTypes(gocRewardBizType, ewtReleaseBizType string) *Config {
+	c.GOCRewardBizType = gocRewardBizType
+	c.EWTReleaseBizType = ewtReleaseBizType
+	return c
+}
+
+// WithAddressResolver 设置用户链上地址查询函数
+func (c *Config) WithAddressResolver(resolver func(ctx context.Context, openId string) (string, error)) *Config {
+	c.AddressResolver = resolver
+	return c
+}
+
+// WithVerifyPreSubmit 开启或关闭流程方法签名前的预提交消息校验
+func (c *Config) WithVerifyPreSubmit(enabled bool) *Config {
+	c.VerifyPreSubmit = enabled
+	return c
+}
+
+// WithVerifyCommitSignature 开启或关闭提交前的本地签名校验
+func (c *Config) WithVerifyCommitSignature(enabled bool) *Config {
+	c.VerifyCommitSignature = enabled
+	return c
+}
@@ -1,5 +1,7 @@
 package junyousdk
 
+import "time"
+
 // API 路径常量
 const (
 	// 注册相关
@@ -30,6 +32,12 @@ const (
 	DefaultAddress     = "https://open-api.junyouchain.com"
 	DefaultVersion     = "v1"
 	DefaultContentType = "application/json"
+	// DefaultSignatureValidity 签名有效期：X-Timestamp 为当前时间加该时长
+	DefaultSignatureValidity = 3 * time.Minute
+	// DefaultTokenTTL Open Token 缓存时长：超过该时长的缓存 Token 重新登录
+	DefaultTokenTTL = 5 * time.Minute
+	// DefaultPageSize 分页查询与遍历的默认每页条数
+	DefaultPageSize = 10
 )
 
 // 认证 Header 常量
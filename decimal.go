@@ -0,0 +1,377 @@
+package junyousdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 金额与比例错误
+var (
+	// ErrInvalidAmount 金额格式错误或超出范围
+	ErrInvalidAmount = errors.New("junyousdk: invalid amount")
+	// ErrInvalidRatio 比例格式错误或超出范围
+	ErrInvalidRatio = errors.New("junyousdk: invalid ratio")
+)
+
+// MaxDecimalScale Amount、Ratio 允许的最多小数位数
+const MaxDecimalScale = 18
+
+// decimalPattern 十进制数格式：可选负号，整数部分无多余前导 0，不含千分位、指数与空白
+var decimalPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+// jsonExponentPattern JSON 数字中的指数部分，如 "e-7"、"E+3"
+var jsonExponentPattern = regexp.MustCompile(`^[eE][+-]?[0-9]+$`)
+
+// maxJSONExponent JSON 数字指数的绝对值上限，防止超大指数展开后占用过多内存
+const maxJSONExponent = 1000
+
+// decimal 精确十进制数：值为 coef × 10^-scale；coef 为 nil 表示未设置。
+// coef 创建后不再修改，decimal 可按值复制。
+type decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+// parseDecimal 解析十进制数字符串，保留原有小数位数（"1.00" 的 scale 为 2）
+func parseDecimal(s string) (decimal, error) {
+	if !decimalPattern.MatchString(s) {
+		return decimal{}, fmt.Errorf("malformed decimal %q", s)
+	}
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if len(fracPart) > MaxDecimalScale {
+		return decimal{}, fmt.Errorf("decimal %q has more than %d fractional digits", s, MaxDecimalScale)
+	}
+	coef, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return decimal{}, fmt.Errorf("malformed decimal %q", s)
+	}
+	return decimal{coef: coef, scale: len(fracPart)}, nil
+}
+
+// parseJSONDecimal 在 parseDecimal 的基础上接受 JSON 数字的指数写法（"1e-7"、"1.5E+3"），按精确十进制展开；
+// 展开后的小数位数同样不超过 MaxDecimalScale
+func parseJSONDecimal(s string) (decimal, error) {
+	idx := strings.IndexAny(s, "eE")
+	if idx == -1 {
+		return parseDecimal(s)
+	}
+	mantissa, exponent := s[:idx], s[idx:]
+	if !decimalPattern.MatchString(mantissa) || !jsonExponentPattern.MatchString(exponent) {
+		return decimal{}, fmt.Errorf("malformed decimal %q", s)
+	}
+	exp, err := strconv.Atoi(exponent[1:])
+	if err != nil || exp > maxJSONExponent || exp < -maxJSONExponent {
+		return decimal{}, fmt.Errorf("decimal %q exponent out of range", s)
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	coef, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return decimal{}, fmt.Errorf("malformed decimal %q", s)
+	}
+	scale := len(fracPart) - exp
+	if scale < 0 {
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil)
+		coef.Mul(coef, factor)
+		scale = 0
+	}
+	if scale > MaxDecimalScale {
+		return decimal{}, fmt.Errorf("decimal %q has more than %d fractional digits", s, MaxDecimalScale)
+	}
+	return decimal{coef: coef, scale: scale}, nil
+}
+
+// unmarshalDecimal 解析 JSON 字符串或数字，均接受指数写法；""、null 为未设置
+func unmarshalDecimal(data []byte) (decimal, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return decimal{}, nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return decimal{}, err
+		}
+		if s == "" {
+			return decimal{}, nil
+		}
+	}
+	return parseJSONDecimal(s)
+}
+
+// set 是否已设置
+func (d decimal) set() bool {
+	return d.coef != nil
+}
+
+// value 返回系数，未设置视为 0
+func (d decimal) value() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale 返回放大到 scale 位小数的系数（scale 不小于 d.scale）
+func (d decimal) rescale(scale int) *big.Int {
+	if scale == d.scale {
+		return d.value()
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil)
+	return factor.Mul(factor, d.value())
+}
+
+// align 将 d、o 对齐到相同小数位数
+func (d decimal) align(o decimal) (x, y *big.Int, scale int) {
+	scale = max(d.scale, o.scale)
+	return d.rescale(scale), o.rescale(scale), scale
+}
+
+func (d decimal) add(o decimal) decimal {
+	x, y, scale := d.align(o)
+	return decimal{coef: new(big.Int).Add(x, y), scale: scale}
+}
+
+func (d decimal) sub(o decimal) decimal {
+	x, y, scale := d.align(o)
+	return decimal{coef: new(big.Int).Sub(x, y), scale: scale}
+}
+
+func (d decimal) mul(o decimal) decimal {
+	return decimal{coef: new(big.Int).Mul(d.value(), o.value()), scale: d.scale + o.scale}
+}
+
+func (d decimal) cmp(o decimal) int {
+	x, y, _ := d.align(o)
+	return x.Cmp(y)
+}
+
+func (d decimal) sign() int {
+	return d.value().Sign()
+}
+
+// truncate 截断到最多 scale 位小数（向零取整）
+func (d decimal) truncate(scale int) decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if d.scale <= scale {
+		return d
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale-scale)), nil)
+	return decimal{coef: new(big.Int).Quo(d.value(), factor), scale: scale}
+}
+
+// normalized 去掉小数末尾 0 后的字符串，数值相等的 decimal 结果相同（"1"、"1.0"、"1.00" 均为 "1"）；未设置时为空字符串
+func (d decimal) normalized() string {
+	if d.coef == nil {
+		return ""
+	}
+	coef, scale := d.coef, d.scale
+	ten := big.NewInt(10)
+	for scale > 0 {
+		q, r := new(big.Int).QuoRem(coef, ten, new(big.Int))
+		if r.Sign() != 0 {
+			break
+		}
+		coef, scale = q, scale-1
+	}
+	return decimal{coef: coef, scale: scale}.String()
+}
+
+// String 按 scale 位小数格式化；未设置时为空字符串
+func (d decimal) String() string {
+	if d.coef == nil {
+		return ""
+	}
+	digits := new(big.Int).Abs(d.coef).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.coef.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Amount 精确十进制金额 / 数量，用于 GOC 金额、权证数量与余额。
+// 零值表示未设置，序列化为 ""；JSON 反序列化接受字符串或数字（含指数写法，如 1e-7），""、null 为未设置。
+// 格式化保留原有小数位数（"1.00" 仍输出 "1.00"），运算不经过 float64。
+type Amount struct {
+	d decimal
+}
+
+// ParseAmount 严格解析金额：十进制数字，不含千分位、指数、正负号与空白，小数不超过 MaxDecimalScale 位
+func ParseAmount(s string) (Amount, error) {
+	if strings.HasPrefix(s, "-") {
+		return Amount{}, fmt.Errorf("%w: %q is negative", ErrInvalidAmount, s)
+	}
+	d, err := parseDecimal(s)
+	if err != nil {
+		return Amount{}, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+	return Amount{d: d}, nil
+}
+
+// MustParseAmount 同 ParseAmount，解析失败时 panic；用于常量金额
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// IsSet 是否已设置
+func (a Amount) IsSet() bool {
+	return a.d.set()
+}
+
+// IsZero 未设置或值为 0
+func (a Amount) IsZero() bool {
+	return a.d.sign() == 0
+}
+
+// Sign 返回 -1、0、1；未设置为 0
+func (a Amount) Sign() int {
+	return a.d.sign()
+}
+
+// Scale 小数位数
+func (a Amount) Scale() int {
+	return a.d.scale
+}
+
+// Cmp 比较 a 与 o，返回 -1、0、1
+func (a Amount) Cmp(o Amount) int {
+	return a.d.cmp(o.d)
+}
+
+// Add 返回 a + o
+func (a Amount) Add(o Amount) Amount {
+	return Amount{d: a.d.add(o.d)}
+}
+
+// Sub 返回 a - o，结果可能为负
+func (a Amount) Sub(o Amount) Amount {
+	return Amount{d: a.d.sub(o.d)}
+}
+
+// Mul 返回 a × r，小数位数为二者之和
+func (a Amount) Mul(r Ratio) Amount {
+	return Amount{d: a.d.mul(r.d)}
+}
+
+// Truncate 截断到最多 scale 位小数（向零取整）
+func (a Amount) Truncate(scale int) Amount {
+	return Amount{d: a.d.truncate(scale)}
+}
+
+// String 十进制字符串；未设置时为空字符串
+func (a Amount) String() string {
+	return a.d.String()
+}
+
+// MarshalJSON 序列化为 JSON 字符串
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.d.String())
+}
+
+// UnmarshalJSON 接受 JSON 字符串或数字；服务端返回的金额可能为负（如转出），不做范围检查
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalDecimal(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+	a.d = d
+	return nil
+}
+
+// Ratio 精确十进制比例，用于权证释放比例。取值单位以服务端为准，SDK 不限定上限。零值、JSON 规则同 Amount
+type Ratio struct {
+	d decimal
+}
+
+// ParseRatio 严格解析比例：格式同 ParseAmount，不接受负数
+func ParseRatio(s string) (Ratio, error) {
+	if strings.HasPrefix(s, "-") {
+		return Ratio{}, fmt.Errorf("%w: %q is negative", ErrInvalidRatio, s)
+	}
+	d, err := parseDecimal(s)
+	if err != nil {
+		return Ratio{}, fmt.Errorf("%w: %v", ErrInvalidRatio, err)
+	}
+	return Ratio{d: d}, nil
+}
+
+// MustParseRatio 同 ParseRatio，解析失败时 panic；用于常量比例
+func MustParseRatio(s string) Ratio {
+	r, err := ParseRatio(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// IsSet 是否已设置
+func (r Ratio) IsSet() bool {
+	return r.d.set()
+}
+
+// IsZero 未设置或值为 0
+func (r Ratio) IsZero() bool {
+	return r.d.sign() == 0
+}
+
+// Sign 返回 -1、0、1；未设置为 0
+func (r Ratio) Sign() int {
+	return r.d.sign()
+}
+
+// Scale 小数位数
+func (r Ratio) Scale() int {
+	return r.d.scale
+}
+
+// Cmp 比较 r 与 o，返回 -1、0、1
+func (r Ratio) Cmp(o Ratio) int {
+	return r.d.cmp(o.d)
+}
+
+// Add 返回 r + o
+func (r Ratio) Add(o Ratio) Ratio {
+	return Ratio{d: r.d.add(o.d)}
+}
+
+// Sub 返回 r - o，结果可能为负
+func (r Ratio) Sub(o Ratio) Ratio {
+	return Ratio{d: r.d.sub(o.d)}
+}
+
+// String 十进制字符串；未设置时为空字符串
+func (r Ratio) String() string {
+	return r.d.String()
+}
+
+// MarshalJSON 序列化为 JSON 字符串
+func (r Ratio) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.d.String())
+}
+
+// UnmarshalJSON 接受 JSON 字符串或数字，只检查格式不检查范围
+func (r *Ratio) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalDecimal(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRatio, err)
+	}
+	r.d = d
+	return nil
+}
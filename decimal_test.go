@@ -0,0 +1,90 @@
+package junyousdk_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+)
+
+func TestParseAmount(t *testing.T) {
+	for _, s := range []string{"0", "1", "1.00", "100", "0.000000000000000001"} {
+		a, err := junyousdk.ParseAmount(s)
+		if err != nil || a.String() != s {
+			t.Errorf("ParseAmount(%q) = %s, %v", s, a, err)
+		}
+	}
+	for _, s := range []string{"", "-1", "+1", "01", "1e3", "1,000", " 1", "1.", ".5", "0.0000000000000000001"} {
+		if _, err := junyousdk.ParseAmount(s); !errors.Is(err, junyousdk.ErrInvalidAmount) {
+			t.Errorf("ParseAmount(%q) err = %v, want ErrInvalidAmount", s, err)
+		}
+	}
+}
+
+func TestParseRatio(t *testing.T) {
+	// 比例的单位以服务端为准，SDK 不限定上限
+	for _, s := range []string{"0", "0.5", "1", "10", "100.25"} {
+		r, err := junyousdk.ParseRatio(s)
+		if err != nil || r.String() != s {
+			t.Errorf("ParseRatio(%q) = %s, %v", s, r, err)
+		}
+	}
+	for _, s := range []string{"", "-0.1", "-10", "1e1", "10%"} {
+		if _, err := junyousdk.ParseRatio(s); !errors.Is(err, junyousdk.ErrInvalidRatio) {
+			t.Errorf("ParseRatio(%q) err = %v, want ErrInvalidRatio", s, err)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Amount junyousdk.Amount `json:"amount"`
+		Ratio  junyousdk.Ratio  `json:"ratio"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount":-1.50,"ratio":"10"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Amount.String() != "-1.50" || v.Ratio.String() != "10" {
+		t.Fatalf("decoded %s / %s", v.Amount, v.Ratio)
+	}
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"amount":"-1.50","ratio":"10"}` {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":null,"ratio":""}`), &v); err != nil || v.Amount.IsSet() || v.Ratio.IsSet() {
+		t.Fatalf("null / empty = %+v, %v; want unset", v, err)
+	}
+}
+
+func TestDecimalJSONExponent(t *testing.T) {
+	tests := map[string]string{
+		`1e-7`:     "0.0000001",
+		`1.5E+3`:   "1500",
+		`-2.50e1`:  "-25.0",
+		`"1e2"`:    "100",
+		`12.34e-2`: "0.1234",
+	}
+	for in, want := range tests {
+		var a junyousdk.Amount
+		if err := json.Unmarshal([]byte(in), &a); err != nil || a.String() != want {
+			t.Errorf("Unmarshal(%s) = %s, %v; want %s", in, a, err, want)
+		}
+	}
+	for _, in := range []string{`1e-19`, `1e1001`, `"1e"`, `"1.e2"`, `"1e+-2"`} {
+		var r junyousdk.Ratio
+		if err := json.Unmarshal([]byte(in), &r); !errors.Is(err, junyousdk.ErrInvalidRatio) {
+			t.Errorf("Unmarshal(%s) err = %v, want ErrInvalidRatio", in, err)
+		}
+	}
+}
+
+func TestAmountMul(t *testing.T) {
+	got := junyousdk.MustParseAmount("100").Mul(junyousdk.MustParseRatio("0.15"))
+	if got.Cmp(junyousdk.MustParseAmount("15")) != 0 || got.Scale() != 2 {
+		t.Fatalf("100 × 0.15 = %s, want 15", got)
+	}
+	if got := junyousdk.MustParseAmount("1.239").Truncate(2); got.String() != "1.23" {
+		t.Fatalf("Truncate = %s, want 1.23", got)
+	}
+}
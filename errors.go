@@ -0,0 +1,138 @@
+package junyousdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// 已知业务错误，可配合 errors.Is 判断 DoRequest 返回的 *APIError：
+//
+//	if errors.Is(err, junyousdk.ErrInsufficientBalance) { ... }
+//
+// 匹配依据：响应带有已注册的 ErrCode（见 ErrCode* 常量与 RegisterErrCode）时只按 ErrCode 判断；
+// 否则按错误消息中的关键字判断。
+var (
+	// ErrMissingUserIdentity 缺少用户身份（未携带或无法解析 X-Open-Auth）
+	ErrMissingUserIdentity = errors.New("junyousdk: missing user identity")
+	// ErrOpenAuthInvalid Open Token 无效或已过期
+	ErrOpenAuthInvalid = errors.New("junyousdk: open auth invalid or expired")
+	// ErrInsufficientBalance 余额不足
+	ErrInsufficientBalance = errors.New("junyousdk: insufficient balance")
+	// ErrDuplicateBizNo 业务单号重复提交
+	ErrDuplicateBizNo = errors.New("junyousdk: duplicate biz_no")
+	// ErrSignatureInvalid 签名校验失败
+	ErrSignatureInvalid = errors.New("junyousdk: signature invalid")
+	// ErrPermissionDenied 无权限或服务未开通
+	ErrPermissionDenied = errors.New("junyousdk: permission denied")
+)
+
+// 预注册的 ErrCode，分别对应上方的已知业务错误。junyoutest 模拟服务按此返回 ErrCode；
+// Open API 文档未列出服务端的 ErrCode 取值，真实服务端的取值与此不同时，可用 RegisterErrCode 补充关联。
+const (
+	ErrCodeMissingUserIdentity = "MISSING_USER_IDENTITY"
+	ErrCodeOpenAuthInvalid     = "OPEN_AUTH_INVALID"
+	ErrCodeInsufficientBalance = "INSUFFICIENT_BALANCE"
+	ErrCodeDuplicateBizNo      = "DUPLICATE_BIZ_NO"
+	ErrCodeSignatureInvalid    = "SIGNATURE_INVALID"
+	ErrCodePermissionDenied    = "PERMISSION_DENIED"
+)
+
+// APIError 接口错误：HTTP 状态码非 200，或业务 code 非 200
+type APIError struct {
+	// StatusCode HTTP 状态码
+	StatusCode int
+	// Code 响应体中的业务状态码（响应体无法解析时为 0）
+	Code int
+	// ErrCode 业务错误代码
+	ErrCode string
+	// APIPath 请求路径（含 query）
+	APIPath string
+	// Message 错误消息
+	Message string
+	// Data 响应中的原始 data（JSON），无则为空
+	Data []byte
+}
+
+// Error 实现 error 接口
+func (e *APIError) Error() string {
+	var msg string
+	if e.StatusCode != http.StatusOK {
+		msg = fmt.Sprintf("http error %d on %s: %s", e.StatusCode, e.APIPath, e.Message)
+	} else {
+		msg = fmt.Sprintf("business error %d on %s: %s", e.Code, e.APIPath, e.Message)
+	}
+	if len(e.Data) > 0 && string(e.Data) != "null" {
+		msg = fmt.Sprintf("%s (data: %s)", msg, string(e.Data))
+	}
+	return msg
+}
+
+// Is 支持 errors.Is 与已知业务错误比较。ErrCode 已注册时只比较其关联的错误，不再匹配消息关键字
+func (e *APIError) Is(target error) bool {
+	if e.ErrCode != "" {
+		errCodeMu.RLock()
+		sentinel, ok := errCodeSentinels[e.ErrCode]
+		errCodeMu.RUnlock()
+		if ok {
+			return sentinel == target
+		}
+	}
+
+	message := normalizeErrorMessage(e.Message)
+	if message == "" {
+		return false
+	}
+	for _, m := range messageSentinels {
+		if m.sentinel != target {
+			continue
+		}
+		for _, keyword := range m.keywords {
+			if strings.Contains(message, keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	errCodeMu        sync.RWMutex
+	errCodeSentinels = map[string]error{
+		ErrCodeMissingUserIdentity: ErrMissingUserIdentity,
+		ErrCodeOpenAuthInvalid:     ErrOpenAuthInvalid,
+		ErrCodeInsufficientBalance: ErrInsufficientBalance,
+		ErrCodeDuplicateBizNo:      ErrDuplicateBizNo,
+		ErrCodeSignatureInvalid:    ErrSignatureInvalid,
+		ErrCodePermissionDenied:    ErrPermissionDenied,
+	}
+)
+
+// RegisterErrCode 将服务端 ErrCode 关联到已知业务错误（或调用方自定义的错误值），
+// 之后返回该 ErrCode 的 *APIError 可通过 errors.Is(err, sentinel) 判断；可覆盖预注册的 ErrCode* 关联。
+func RegisterErrCode(errCode string, sentinel error) {
+	errCodeMu.Lock()
+	defer errCodeMu.Unlock()
+	errCodeSentinels[errCode] = sentinel
+}
+
+// messageSentinels 按错误消息关键字（小写、去空白）匹配已知业务错误，用于未返回 ErrCode 或 ErrCode 未注册的响应。
+// 关键字须足够具体，避免把无关错误误判为已知错误
+var messageSentinels = []struct {
+	sentinel error
+	keywords []string
+}{
+	{ErrMissingUserIdentity, []string{"缺少用户身份", "missinguseridentity"}},
+	{ErrOpenAuthInvalid, []string{"token无效", "token已过期", "token过期", "token失效", "令牌无效", "令牌已过期", "令牌失效", "invalidtoken", "tokenexpired"}},
+	{ErrInsufficientBalance, []string{"余额不足", "insufficientbalance"}},
+	{ErrDuplicateBizNo, []string{"单号重复提交", "单号已存在", "单号重复", "duplicatebiz_no", "duplicatebizno"}},
+	{ErrSignatureInvalid, []string{"签名错误", "签名无效", "签名校验失败", "验签失败", "invalidsignature", "signatureinvalid"}},
+	{ErrPermissionDenied, []string{"无权限", "未开通", "permissiondenied"}},
+}
+
+// normalizeErrorMessage 规范化错误消息：转小写并去除空白
+func normalizeErrorMessage(message string) string {
+	return strings.Join(strings.Fields(strings.ToLower(message)), "")
+}
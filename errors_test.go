@@ -0,0 +1,108 @@
+package junyousdk_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+func TestAPIErrorIsByMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		target  error
+		want    bool
+	}{
+		{"校验失败：缺少用户身份", junyousdk.ErrMissingUserIdentity, true},
+		{"Token 已过期", junyousdk.ErrOpenAuthInvalid, true},
+		{"余额不足", junyousdk.ErrInsufficientBalance, true},
+		{"业务单号重复提交", junyousdk.ErrDuplicateBizNo, true},
+		{"Duplicate biz_no GOC1", junyousdk.ErrDuplicateBizNo, true},
+		{"duplicate phone number", junyousdk.ErrDuplicateBizNo, false},
+		{"duplicate request", junyousdk.ErrDuplicateBizNo, false},
+		{"验签失败", junyousdk.ErrSignatureInvalid, true},
+		{"服务未开通", junyousdk.ErrPermissionDenied, true},
+		{"余额不足", junyousdk.ErrSignatureInvalid, false},
+		{"", junyousdk.ErrInsufficientBalance, false},
+	}
+	for _, tt := range tests {
+		err := error(&junyousdk.APIError{StatusCode: http.StatusOK, Code: http.StatusBadRequest, Message: tt.message})
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%q, %v) = %v, want %v", tt.message, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorIsByErrCode(t *testing.T) {
+	// 已注册的 ErrCode 优先，消息关键字不再参与匹配
+	err := error(&junyousdk.APIError{
+		StatusCode: http.StatusUnauthorized,
+		ErrCode:    junyousdk.ErrCodeSignatureInvalid,
+		Message:    "junyousdk: signature mismatch (余额不足)",
+	})
+	if !errors.Is(err, junyousdk.ErrSignatureInvalid) {
+		t.Error("errors.Is(err, ErrSignatureInvalid) = false, want true")
+	}
+	if errors.Is(err, junyousdk.ErrInsufficientBalance) {
+		t.Error("errors.Is(err, ErrInsufficientBalance) = true, want false when ErrCode is registered")
+	}
+
+	// 未注册的 ErrCode 回退到消息关键字
+	err = &junyousdk.APIError{ErrCode: "SOMETHING_ELSE", Message: "余额不足"}
+	if !errors.Is(err, junyousdk.ErrInsufficientBalance) {
+		t.Error("unregistered ErrCode: errors.Is(err, ErrInsufficientBalance) = false, want true")
+	}
+
+	custom := errors.New("quota exceeded")
+	junyousdk.RegisterErrCode("TEST_QUOTA_EXCEEDED", custom)
+	err = &junyousdk.APIError{ErrCode: "TEST_QUOTA_EXCEEDED", Message: "quota"}
+	if !errors.Is(err, custom) {
+		t.Error("errors.Is(err, custom) = false after RegisterErrCode")
+	}
+}
+
+func TestAPIErrorFromServer(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+
+	client, err := junyousdk.NewClient(srv.Config().WithAccessKey("d3Jvbmcta2V5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := client.API().Register(&junyousdk.RegisterInfo{PhoneNumber: "13800138000"})
+	if !errors.Is(err, junyousdk.ErrSignatureInvalid) {
+		t.Fatalf("err = %v, want ErrSignatureInvalid", err)
+	}
+	var apiErr *junyousdk.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %T, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || apiErr.ErrCode != junyousdk.ErrCodeSignatureInvalid || apiErr.APIPath != junyousdk.APIPathRegister {
+		t.Fatalf("apiErr = %+v", apiErr)
+	}
+	if result == nil || result.Code != http.StatusUnauthorized || result.ErrCode != junyousdk.ErrCodeSignatureInvalid {
+		t.Fatalf("result = %+v", result)
+	}
+
+	client, err = junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.SetEnterpriseGOCBalance("1"); err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	login, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: openId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.API().PreRewardGOC(junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("5")}, login.Data)
+	if !errors.Is(err, junyousdk.ErrInsufficientBalance) {
+		t.Fatalf("err = %v, want ErrInsufficientBalance", err)
+	}
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusOK || apiErr.Code != http.StatusBadRequest {
+		t.Fatalf("apiErr = %+v, want business error", apiErr)
+	}
+}
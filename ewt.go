@@ -1,6 +1,7 @@
 package junyousdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -16,12 +17,12 @@ type EWTBizNoInfo struct {
 // PreEWTReleaseByPartnerRequest 预提交权证释放请求体
 // 对应接口: POST /api/open/v1/ewt/pre_ewt_rbp_open
 type PreEWTReleaseByPartnerRequest struct {
-	Amount       string `json:"amount"`         // 权证数量
-	Ratio        string `json:"ratio"`          // 总释放比例
+	Amount       Amount `json:"amount"`         // 权证数量
+	Ratio        Ratio  `json:"ratio"`          // 总释放比例
 	Level1OpenId string `json:"level1_open_id"` // 一级合伙人 OpenId
-	Level1Ratio  string `json:"level1_ratio"`   // 一级合伙人分配比例
+	Level1Ratio  Ratio  `json:"level1_ratio"`   // 一级合伙人分配比例，未设置时序列化为 ""
 	Level2OpenId string `json:"level2_open_id"` // 二级合伙人 OpenId
-	Level2Ratio  string `json:"level2_ratio"`   // 二级合伙人分配比例
+	Level2Ratio  Ratio  `json:"level2_ratio"`   // 二级合伙人分配比例，未设置时序列化为 ""
 }
 
 // CommitEWTReleaseByPartnerRequest 提交权证释放（伙伴）请求体
@@ -35,7 +36,12 @@ type CommitEWTReleaseByPartnerRequest struct {
 
 // ConfirmEWTReleaseByPartner 确认权证释放（合作伙伴）
 func (s *APIService) ConfirmEWTReleaseByPartner(ewtBizNoInfo EWTBizNoInfo) (*Result[string], error) {
-	return DoRequest[string](s.client,
+	return s.ConfirmEWTReleaseByPartnerContext(context.Background(), ewtBizNoInfo)
+}
+
+// ConfirmEWTReleaseByPartnerContext 同 ConfirmEWTReleaseByPartner，ctx 用于取消与超时控制
+func (s *APIService) ConfirmEWTReleaseByPartnerContext(ctx context.Context, ewtBizNoInfo EWTBizNoInfo) (*Result[string], error) {
+	return DoRequestContext[string](ctx, s.client,
 		http.MethodPost,
 		APIPathEWTConfirmReleaseByPartner,
 		ewtBizNoInfo,
@@ -47,7 +53,33 @@ func (s *APIService) ConfirmEWTReleaseByPartner(ewtBizNoInfo EWTBizNoInfo) (*Res
 // 对应接口: POST /api/open/v1/ewt/pre_ewt_rbp_open
 // openAuth 为接收权证释放的用户的 Open Token（X-Open-Auth）；空或仅空白则不带该头。该接口需要用户身份，未带时服务端可能返回「校验失败：缺少用户身份」。openAuth 可通过 /api/open/v1/auth/login 等开放接口换取。
 func (s *APIService) PreCommitEWTReleaseByPartner(req PreEWTReleaseByPartnerRequest, openAuth string) (*Result[map[string]any], error) {
-	return DoRequest[map[string]any](s.client,
+	return s.PreCommitEWTReleaseByPartnerContext(context.Background(), req, openAuth)
+}
+
+// PreCommitEWTReleaseByPartnerContext 同 PreCommitEWTReleaseByPartner，ctx 用于取消与超时控制
+func (s *APIService) PreCommitEWTReleaseByPartnerContext(ctx context.Context, req PreEWTReleaseByPartnerRequest, openAuth string) (*Result[map[string]any], error) {
+	if err := req.Validate(); err != nil {
+		return NewParamErrorResult[map[string]any](err.Error()), err
+	}
+	return DoRequestContext[map[string]any](ctx, s.client,
+		http.MethodPost,
+		APIPathEWTPreOpenReleaseByPartner,
+		req,
+		openAuthExtraHeaders(openAuth),
+	)
+}
+
+// PreCommitEWTReleaseByPartnerMessage 同 PreCommitEWTReleaseByPartner，Data 解析为 EWTReleaseMessage
+func (s *APIService) PreCommitEWTReleaseByPartnerMessage(req PreEWTReleaseByPartnerRequest, openAuth string) (*Result[EWTReleaseMessage], error) {
+	return s.PreCommitEWTReleaseByPartnerMessageContext(context.Background(), req, openAuth)
+}
+
+// PreCommitEWTReleaseByPartnerMessageContext 同 PreCommitEWTReleaseByPartnerMessage，ctx 用于取消与超时控制
+func (s *APIService) PreCommitEWTReleaseByPartnerMessageContext(ctx context.Context, req PreEWTReleaseByPartnerRequest, openAuth string) (*Result[EWTReleaseMessage], error) {
+	if err := req.Validate(); err != nil {
+		return NewParamErrorResult[EWTReleaseMessage](err.Error()), err
+	}
+	return DoRequestContext[EWTReleaseMessage](ctx, s.client,
 		http.MethodPost,
 		APIPathEWTPreOpenReleaseByPartner,
 		req,
@@ -55,10 +87,66 @@ func (s *APIService) PreCommitEWTReleaseByPartner(req PreEWTReleaseByPartnerRequ
 	)
 }
 
+// PreCommitEWTReleaseByPartnerByOpenId 同 PreCommitEWTReleaseByPartner，由 Client.Tokens 为 openId 换取新 Token 作为 X-Open-Auth
+func (s *APIService) PreCommitEWTReleaseByPartnerByOpenId(req PreEWTReleaseByPartnerRequest, openId string) (*Result[map[string]any], error) {
+	return s.PreCommitEWTReleaseByPartnerByOpenIdContext(context.Background(), req, openId)
+}
+
+// PreCommitEWTReleaseByPartnerByOpenIdContext 同 PreCommitEWTReleaseByPartnerByOpenId，ctx 用于取消与超时控制
+func (s *APIService) PreCommitEWTReleaseByPartnerByOpenIdContext(ctx context.Context, req PreEWTReleaseByPartnerRequest, openId string) (*Result[map[string]any], error) {
+	if err := req.Validate(); err != nil {
+		return NewParamErrorResult[map[string]any](err.Error()), err
+	}
+	return callByOpenId(ctx, s, openId, TokenFresh, func(openAuth string) (*Result[map[string]any], error) {
+		return s.PreCommitEWTReleaseByPartnerContext(ctx, req, openAuth)
+	})
+}
+
+// PreCommitEWTReleaseByPartnerMessageByOpenId 同 PreCommitEWTReleaseByPartnerMessage，由 Client.Tokens 为 openId 换取新 Token 作为 X-Open-Auth
+func (s *APIService) PreCommitEWTReleaseByPartnerMessageByOpenId(req PreEWTReleaseByPartnerRequest, openId string) (*Result[EWTReleaseMessage], error) {
+	return s.PreCommitEWTReleaseByPartnerMessageByOpenIdContext(context.Background(), req, openId)
+}
+
+// PreCommitEWTReleaseByPartnerMessageByOpenIdContext 同 PreCommitEWTReleaseByPartnerMessageByOpenId，ctx 用于取消与超时控制
+func (s *APIService) PreCommitEWTReleaseByPartnerMessageByOpenIdContext(ctx context.Context, req PreEWTReleaseByPartnerRequest, openId string) (*Result[EWTReleaseMessage], error) {
+	if err := req.Validate(); err != nil {
+		return NewParamErrorResult[EWTReleaseMessage](err.Error()), err
+	}
+	return callByOpenId(ctx, s, openId, TokenFresh, func(openAuth string) (*Result[EWTReleaseMessage], error) {
+		return s.PreCommitEWTReleaseByPartnerMessageContext(ctx, req, openAuth)
+	})
+}
+
 // CommitEWTReleaseByPartner 提交权证释放（伙伴）
 // 对应接口: POST /api/open/v1/ewt/commit_ewt_rbp
 func (s *APIService) CommitEWTReleaseByPartner(req CommitEWTReleaseByPartnerRequest) (*Result[map[string]any], error) {
-	return DoRequest[map[string]any](s.client,
+	return s.CommitEWTReleaseByPartnerContext(context.Background(), req)
+}
+
+// CommitEWTReleaseByPartnerContext 同 CommitEWTReleaseByPartner，ctx 用于取消与超时控制
+func (s *APIService) CommitEWTReleaseByPartnerContext(ctx context.Context, req CommitEWTReleaseByPartnerRequest) (*Result[map[string]any], error) {
+	if err := s.checkCommitSignature(req.Message, req.PublicKey, req.DerHex); err != nil {
+		return NewParamErrorResult[map[string]any](err.Error()), err
+	}
+	return DoRequestContext[map[string]any](ctx, s.client,
+		http.MethodPost,
+		APIPathEWTCommitReleaseByPartner,
+		req,
+		nil,
+	)
+}
+
+// CommitEWTReleaseByPartnerReceipt 同 CommitEWTReleaseByPartner，Data 解析为 CommitReceipt
+func (s *APIService) CommitEWTReleaseByPartnerReceipt(req CommitEWTReleaseByPartnerRequest) (*Result[CommitReceipt], error) {
+	return s.CommitEWTReleaseByPartnerReceiptContext(context.Background(), req)
+}
+
+// CommitEWTReleaseByPartnerReceiptContext 同 CommitEWTReleaseByPartnerReceipt，ctx 用于取消与超时控制
+func (s *APIService) CommitEWTReleaseByPartnerReceiptContext(ctx context.Context, req CommitEWTReleaseByPartnerRequest) (*Result[CommitReceipt], error) {
+	if err := s.checkCommitSignature(req.Message, req.PublicKey, req.DerHex); err != nil {
+		return NewParamErrorResult[CommitReceipt](err.Error()), err
+	}
+	return DoRequestContext[CommitReceipt](ctx, s.client,
 		http.MethodPost,
 		APIPathEWTCommitReleaseByPartner,
 		req,
@@ -70,24 +158,72 @@ func (s *APIService) CommitEWTReleaseByPartner(req CommitEWTReleaseByPartnerRequ
 // 对应接口: GET /api/open/v1/ewt/balance?page&page_size
 // openAuth 为空或仅空白时不带 X-Open-Auth，按企业维度查询；否则为 AuthLogin 返回的 Open Token，按该用户维度查询。
 func (s *APIService) GetEWTBalance(page, pageSize int, openAuth string) (*Result[map[string]any], error) {
+	return s.GetEWTBalanceContext(context.Background(), page, pageSize, openAuth)
+}
+
+// GetEWTBalanceContext 同 GetEWTBalance，ctx 用于取消与超时控制
+func (s *APIService) GetEWTBalanceContext(ctx context.Context, page, pageSize int, openAuth string) (*Result[map[string]any], error) {
+	return DoRequestContext[map[string]any](ctx, s.client,
+		http.MethodGet,
+		ewtBalancePath(page, pageSize),
+		nil,
+		openAuthExtraHeaders(openAuth),
+	)
+}
+
+// GetEWTBalancePage 同 GetEWTBalance，Data 解析为 EWTBalancePage
+func (s *APIService) GetEWTBalancePage(page, pageSize int, openAuth string) (*Result[EWTBalancePage], error) {
+	return s.GetEWTBalancePageContext(context.Background(), page, pageSize, openAuth)
+}
+
+// GetEWTBalancePageContext 同 GetEWTBalancePage，ctx 用于取消与超时控制
+func (s *APIService) GetEWTBalancePageContext(ctx context.Context, page, pageSize int, openAuth string) (*Result[EWTBalancePage], error) {
+	return DoRequestContext[EWTBalancePage](ctx, s.client,
+		http.MethodGet,
+		ewtBalancePath(page, pageSize),
+		nil,
+		openAuthExtraHeaders(openAuth),
+	)
+}
+
+// GetEWTBalanceByOpenId 同 GetEWTBalance，按 openId 用户维度查询，X-Open-Auth 取自 Client.Tokens 的缓存 Token
+func (s *APIService) GetEWTBalanceByOpenId(page, pageSize int, openId string) (*Result[map[string]any], error) {
+	return s.GetEWTBalanceByOpenIdContext(context.Background(), page, pageSize, openId)
+}
+
+// GetEWTBalanceByOpenIdContext 同 GetEWTBalanceByOpenId，ctx 用于取消与超时控制
+func (s *APIService) GetEWTBalanceByOpenIdContext(ctx context.Context, page, pageSize int, openId string) (*Result[map[string]any], error) {
+	return callByOpenId(ctx, s, openId, TokenCached, func(openAuth string) (*Result[map[string]any], error) {
+		return s.GetEWTBalanceContext(ctx, page, pageSize, openAuth)
+	})
+}
+
+// GetEWTBalancePageByOpenId 同 GetEWTBalancePage，按 openId 用户维度查询，X-Open-Auth 取自 Client.Tokens 的缓存 Token
+func (s *APIService) GetEWTBalancePageByOpenId(page, pageSize int, openId string) (*Result[EWTBalancePage], error) {
+	return s.GetEWTBalancePageByOpenIdContext(context.Background(), page, pageSize, openId)
+}
+
+// GetEWTBalancePageByOpenIdContext 同 GetEWTBalancePageByOpenId，ctx 用于取消与超时控制
+func (s *APIService) GetEWTBalancePageByOpenIdContext(ctx context.Context, page, pageSize int, openId string) (*Result[EWTBalancePage], error) {
+	return callByOpenId(ctx, s, openId, TokenCached, func(openAuth string) (*Result[EWTBalancePage], error) {
+		return s.GetEWTBalancePageContext(ctx, page, pageSize, openAuth)
+	})
+}
+
+// ewtBalancePath 构建权证余额查询路径；page、pageSize 非正时分别取 1、DefaultPageSize
+func ewtBalancePath(page, pageSize int) string {
 	if page <= 0 {
 		page = 1
 	}
 	if pageSize <= 0 {
-		pageSize = 10
+		pageSize = DefaultPageSize
 	}
 
 	query := url.Values{}
 	query.Set("page", fmt.Sprintf("%d", page))
 	query.Set("page_size", fmt.Sprintf("%d", pageSize))
 
-	apiPath := fmt.Sprintf("%s?%s", APIPathEWTBalance, query.Encode())
-	return DoRequest[map[string]any](s.client,
-		http.MethodGet,
-		apiPath,
-		nil,
-		openAuthExtraHeaders(openAuth),
-	)
+	return fmt.Sprintf("%s?%s", APIPathEWTBalance, query.Encode())
 }
 
 // GetEWTTransactionDetails 权证交易明细查询
@@ -99,11 +235,124 @@ func (s *APIService) GetEWTTransactionDetails(
 	year, month int,
 	openAuth string,
 ) (*Result[map[string]any], error) {
+	return s.GetEWTTransactionDetailsContext(context.Background(),
+		page, pageSize,
+		transactionType, bizType,
+		year, month,
+		openAuth,
+	)
+}
+
+// GetEWTTransactionDetailsContext 同 GetEWTTransactionDetails，ctx 用于取消与超时控制
+func (s *APIService) GetEWTTransactionDetailsContext(
+	ctx context.Context,
+	page, pageSize int,
+	transactionType, bizType string,
+	year, month int,
+	openAuth string,
+) (*Result[map[string]any], error) {
+	return DoRequestContext[map[string]any](ctx, s.client,
+		http.MethodGet,
+		ewtTransactionDetailsPath(page, pageSize, transactionType, bizType, year, month),
+		nil,
+		openAuthExtraHeaders(openAuth),
+	)
+}
+
+// GetEWTTransactionPage 同 GetEWTTransactionDetails，Data 解析为 EWTTransactionPage
+func (s *APIService) GetEWTTransactionPage(
+	page, pageSize int,
+	transactionType, bizType string,
+	year, month int,
+	openAuth string,
+) (*Result[EWTTransactionPage], error) {
+	return s.GetEWTTransactionPageContext(context.Background(),
+		page, pageSize,
+		transactionType, bizType,
+		year, month,
+		openAuth,
+	)
+}
+
+// GetEWTTransactionPageContext 同 GetEWTTransactionPage，ctx 用于取消与超时控制
+func (s *APIService) GetEWTTransactionPageContext(
+	ctx context.Context,
+	page, pageSize int,
+	transactionType, bizType string,
+	year, month int,
+	openAuth string,
+) (*Result[EWTTransactionPage], error) {
+	return DoRequestContext[EWTTransactionPage](ctx, s.client,
+		http.MethodGet,
+		ewtTransactionDetailsPath(page, pageSize, transactionType, bizType, year, month),
+		nil,
+		openAuthExtraHeaders(openAuth),
+	)
+}
+
+// GetEWTTransactionDetailsByOpenId 同 GetEWTTransactionDetails，按 openId 用户维度查询，X-Open-Auth 取自 Client.Tokens 的缓存 Token
+func (s *APIService) GetEWTTransactionDetailsByOpenId(
+	page, pageSize int,
+	transactionType, bizType string,
+	year, month int,
+	openId string,
+) (*Result[map[string]any], error) {
+	return s.GetEWTTransactionDetailsByOpenIdContext(context.Background(),
+		page, pageSize,
+		transactionType, bizType,
+		year, month,
+		openId,
+	)
+}
+
+// GetEWTTransactionDetailsByOpenIdContext 同 GetEWTTransactionDetailsByOpenId，ctx 用于取消与超时控制
+func (s *APIService) GetEWTTransactionDetailsByOpenIdContext(
+	ctx context.Context,
+	page, pageSize int,
+	transactionType, bizType string,
+	year, month int,
+	openId string,
+) (*Result[map[string]any], error) {
+	return callByOpenId(ctx, s, openId, TokenCached, func(openAuth string) (*Result[map[string]any], error) {
+		return s.GetEWTTransactionDetailsContext(ctx, page, pageSize, transactionType, bizType, year, month, openAuth)
+	})
+}
+
+// GetEWTTransactionPageByOpenId 同 GetEWTTransactionPage，按 openId 用户维度查询，X-Open-Auth 取自 Client.Tokens 的缓存 Token
+func (s *APIService) GetEWTTransactionPageByOpenId(
+	page, pageSize int,
+	transactionType, bizType string,
+	year, month int,
+	openId string,
+) (*Result[EWTTransactionPage], error) {
+	return s.GetEWTTransactionPageByOpenIdContext(context.Background(),
+		page, pageSize,
+		transactionType, bizType,
+		year, month,
+		openId,
+	)
+}
+
+// GetEWTTransactionPageByOpenIdContext 同 GetEWTTransactionPageByOpenId，ctx 用于取消与超时控制
+func (s *APIService) GetEWTTransactionPageByOpenIdContext(
+	ctx context.Context,
+	page, pageSize int,
+	transactionType, bizType string,
+	year, month int,
+	openId string,
+) (*Result[EWTTransactionPage], error) {
+	return callByOpenId(ctx, s, openId, TokenCached, func(openAuth string) (*Result[EWTTransactionPage], error) {
+		return s.GetEWTTransactionPageContext(ctx, page, pageSize, transactionType, bizType, year, month, openAuth)
+	})
+}
+
+// ewtTransactionDetailsPath 构建权证交易明细查询路径；空字符串与非正数的筛选条件不带入 query
+func ewtTransactionDetailsPath(page, pageSize int, transactionType, bizType string, year, month int) string {
 	if page <= 0 {
 		page = 1
 	}
 	if pageSize <= 0 {
-		pageSize = 10
+		pageSize = DefaultPageSize
 	}
 
 	query := url.Values{}
@@ -122,13 +371,7 @@ func (s *APIService) GetEWTTransactionDetails(
 		query.Set("month", fmt.Sprintf("%d", month))
 	}
 
-	apiPath := fmt.Sprintf("%s?%s", APIPathEWTTransactionDetails, query.Encode())
-	return DoRequest[map[string]any](s.client,
-		http.MethodGet,
-		apiPath,
-		nil,
-		openAuthExtraHeaders(openAuth),
-	)
+	return fmt.Sprintf("%s?%s", APIPathEWTTransactionDetails, query.Encode())
 }
 
 // openAuthExtraHeaders 将 Open Token 转为 DoRequest 的 extraHeaders；空或仅空白返回 nil。
@@ -1,9 +1,11 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
 
 	junyousdk "github.com/junyouava/junyou-sdk-go"
 )
@@ -20,6 +22,12 @@ func main() {
 		log.Fatalf("创建客户端失败: %v\n", err)
 	}
 
+	// 链上消息签名器：私钥（secp256k1 十六进制）从环境变量读取，也可用 NewLocalSignerFromPEM 加载 PEM
+	signer, err := junyousdk.NewLocalSignerFromHex(os.Getenv("JUNYOU_SIGNER_KEY"))
+	if err != nil {
+		log.Fatalf("加载签名私钥失败: %v\n", err)
+	}
+
 	// 示例1: 注册
 	// registerExample(client)
 
@@ -31,11 +39,17 @@ func main() {
 
 	// 示例5: 权证合伙人释放（预提交 + 提交，与 GOC 示例同一套路）
 	// 对应接口: POST /api/open/v1/ewt/pre_ewt_rbp_open 、 POST /api/open/v1/ewt/commit_ewt_rbp
-	// ewtReleaseByPartnerExample(client)
+	// ewtReleaseByPartnerExample(client, signer)
 
-	// 示例5b: GOC 预提交 + 提交（AuthLogin → PreRewardGOC+openAuth → json.Marshal(Data) → 签名 → RewardGOC）
+	// 示例5a: 权证合伙人释放一键完成（ReleaseEWTByPartner：预提交 → 签名 → 提交 → 确认）
+	// ewtReleaseFlowExample(client, signer)
+
+	// 示例5b: GOC 预提交 + 提交（AuthLogin → PreRewardGOC+openAuth → PreSubmitMessage → 签名 → RewardGOC）
 	// 对应接口: POST /api/open/v1/goc/pre_reward 、 POST /api/open/v1/goc/reward
-	gocRewardExample(client)
+	gocRewardExample(client, signer)
+
+	// 示例5c: GOC 一键发放（RewardGOCFlow 封装 5b 的全部步骤）
+	// gocRewardFlowExample(client, signer)
 
 	// 示例7: 权证余额查询
 	// 对应接口: GET /api/open/v1/ewt/balance
@@ -111,9 +125,9 @@ func ewtExample(client *junyousdk.Client) {
 
 // ewtReleaseByPartnerExample 权证合伙人释放：AuthLogin → 预提交 → 取待签名对象 → 提交
 // 对应接口: POST /api/open/v1/ewt/pre_ewt_rbp_open 、 POST /api/open/v1/ewt/commit_ewt_rbp
-// 预提交须带 X-Open-Auth（先 AuthLogin）。message = string(json.Marshal(preResult.Data))，与 GOC 示例一致。
-// TODO: public_key、der_hex 请换为密盾对 message 的真实签名结果。
-func ewtReleaseByPartnerExample(client *junyousdk.Client) {
+// 预提交须带 X-Open-Auth（先 AuthLogin）。message = junyousdk.PreSubmitMessage(preResult)，即预提交 data 的原始字节，与 GOC 示例一致。
+// public_key、der_hex 由 signer 对 message 签名得到；接入密盾时可用 junyousdk.SignerFunc 包装。
+func ewtReleaseByPartnerExample(client *junyousdk.Client, signer junyousdk.Signer) {
 	fmt.Println("\n=== 权证合伙人释放：预提交 + 提交示例 ===")
 
 	openId := "04a7bb30587780d34fd7916664b13651ee4a05dc8079c34a69e9cea2cc59faf7"
@@ -129,12 +143,12 @@ func ewtReleaseByPartnerExample(client *junyousdk.Client) {
 	openAuth := loginResult.Data
 
 	preReq := junyousdk.PreEWTReleaseByPartnerRequest{
-		Amount:       "100",
-		Ratio:        "1",
+		Amount:       junyousdk.MustParseAmount("100"),
+		Ratio:        junyousdk.MustParseRatio("1"),
 		Level1OpenId: "04a7bb30587780d34fd7916664b13651ee4a05dc8079c34a69e9cea2cc59faf7",
-		Level1Ratio:  "0.7",
+		Level1Ratio:  junyousdk.MustParseRatio("0.7"),
 		Level2OpenId: "d92067abdbb2e2b68a4ad31597e45c1944389c0b26324233a4498a9066037369",
-		Level2Ratio:  "0.3",
+		Level2Ratio:  junyousdk.MustParseRatio("0.3"),
 	}
 
 	preResult, err := client.API().PreCommitEWTReleaseByPartner(preReq, openAuth)
@@ -148,12 +162,11 @@ func ewtReleaseByPartnerExample(client *junyousdk.Client) {
 	}
 	fmt.Printf("预提交成功，返回数据: %#v\n", preResult.Data)
 
-	msgBytes, err := json.Marshal(preResult.Data)
+	message, err := junyousdk.PreSubmitMessage(preResult)
 	if err != nil {
-		log.Printf("序列化 message 失败: %v\n", err)
+		log.Printf("获取待签名 message 失败: %v\n", err)
 		return
 	}
-	message := string(msgBytes)
 
 	bizNo, _ := preResult.Data["biz_no"].(string)
 	if bizNo == "" {
@@ -161,11 +174,17 @@ func ewtReleaseByPartnerExample(client *junyousdk.Client) {
 		return
 	}
 
+	publicKey, derHex, err := signer.Sign([]byte(message))
+	if err != nil {
+		log.Printf("签名失败: %v\n", err)
+		return
+	}
+
 	commitReq := junyousdk.CommitEWTReleaseByPartnerRequest{
 		BizNo:     bizNo,
 		Message:   message,
-		PublicKey: "04a1b2c3d4e5f678901234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
-		DerHex:    "3045022100a1b2c3d4e5f678901234567890abcdef1234567890abcdef1234567890abcdef1234567890022001234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+		PublicKey: publicKey,
+		DerHex:    derHex,
 	}
 
 	commitResult, err := client.API().CommitEWTReleaseByPartner(commitReq)
@@ -230,8 +249,8 @@ func ewtTransactionDetailsExample(client *junyousdk.Client) {
 
 // gocRewardExample GOC：AuthLogin(收款方 open_id) → 预提交（body 仅 amount + 必填 X-Open-Auth；每次预提交须新 Token）→ 签名 → 提交（reward 可不带头）
 // 对应接口: POST /api/open/v1/goc/pre_reward 、 POST /api/open/v1/goc/reward
-// TODO: public_key、der_hex 请换为密盾对 message 的真实签名结果。
-func gocRewardExample(client *junyousdk.Client) {
+// public_key、der_hex 由 signer 对 message 签名得到；接入密盾时可用 junyousdk.SignerFunc 包装。
+func gocRewardExample(client *junyousdk.Client, signer junyousdk.Signer) {
 	fmt.Println("\n=== GOC 预提交 + 提交示例 ===")
 
 	openId := "8d007704b1954336e0928c465745c1e87782f5390c1ec784722e63eadf6af6bf"
@@ -246,8 +265,14 @@ func gocRewardExample(client *junyousdk.Client) {
 	}
 	openAuth := loginResult.Data
 
+	// 金额先在本地严格解析，"1,00"、"-5" 等在签名与发送前即被拒绝
+	amount, err := junyousdk.ParseAmount("1.00")
+	if err != nil {
+		log.Printf("金额无效: %v\n", err)
+		return
+	}
 	preReq := junyousdk.PreGOCRewardRequest{
-		Amount: "1.00",
+		Amount: amount,
 	}
 
 	preResult, err := client.API().PreRewardGOC(preReq, openAuth)
@@ -261,12 +286,11 @@ func gocRewardExample(client *junyousdk.Client) {
 	}
 	fmt.Printf("预提交成功，返回数据: %#v\n", preResult.Data)
 
-	msgBytes, err := json.Marshal(preResult.Data)
+	message, err := junyousdk.PreSubmitMessage(preResult)
 	if err != nil {
-		log.Printf("序列化 message 失败: %v\n", err)
+		log.Printf("获取待签名 message 失败: %v\n", err)
 		return
 	}
-	message := string(msgBytes)
 	fmt.Println("message", message)
 
 	bizNo, _ := preResult.Data["biz_no"].(string)
@@ -275,11 +299,17 @@ func gocRewardExample(client *junyousdk.Client) {
 		return
 	}
 
+	publicKey, derHex, err := signer.Sign([]byte(message))
+	if err != nil {
+		log.Printf("签名失败: %v\n", err)
+		return
+	}
+
 	commitReq := junyousdk.CommitGOCRewardRequest{
 		BizNo:     bizNo,
 		Message:   message,
-		PublicKey: `{"Curvname":"P-256","X":"N7H1oyS-4s-ZbsoS4orISoDDYP-QGXlUCDEU0jeicOM","Y":"-bxc-QAmzoWoDF8AJambw77IL9mB9iKHgdX2kT0sVL0"}`,
-		DerHex:    "304502206256c1bb7fd9f4ec17a4dc6150e955eda450b1e1bbd3610ebc2e0b73cdc2348d022100f1633e11aa3bfe580fec901e312e827a60828e31015e289e8f1a58f562241d50",
+		PublicKey: publicKey,
+		DerHex:    derHex,
 	}
 
 	commitResult, err := client.API().RewardGOC(commitReq)
@@ -294,3 +324,48 @@ func gocRewardExample(client *junyousdk.Client) {
 
 	fmt.Printf("GOC 提交成功，返回数据: %#v\n", commitResult)
 }
+
+// gocRewardFlowExample GOC 一键发放：RewardGOCFlow 依次完成 AuthLogin → 预提交 → 签名 → 提交
+func gocRewardFlowExample(client *junyousdk.Client, signer junyousdk.Signer) {
+	fmt.Println("\n=== GOC 一键发放示例 ===")
+
+	openId := "8d007704b1954336e0928c465745c1e87782f5390c1ec784722e63eadf6af6bf"
+	report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("1.00"), signer)
+	if err != nil {
+		var flowErr *junyousdk.FlowError
+		if errors.As(err, &flowErr) {
+			log.Printf("GOC 发放在 %s 步骤失败: %v\n", flowErr.Step, flowErr.Err)
+			return
+		}
+		log.Printf("GOC 发放失败: %v\n", err)
+		return
+	}
+
+	fmt.Printf("GOC 发放成功，biz_no: %s，tx_hash: %s\n", report.BizNo, report.Commit.Data.TxHash)
+}
+
+// ewtReleaseFlowExample 权证合伙人释放一键完成：ReleaseEWTByPartner 依次完成 AuthLogin → 预提交 → 签名 → 提交 → 确认
+func ewtReleaseFlowExample(client *junyousdk.Client, signer junyousdk.Signer) {
+	fmt.Println("\n=== 权证合伙人释放一键完成示例 ===")
+
+	receiverOpenId := "04a7bb30587780d34fd7916664b13651ee4a05dc8079c34a69e9cea2cc59faf7"
+	report, err := client.API().ReleaseEWTByPartner(context.Background(), receiverOpenId, junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount:       junyousdk.MustParseAmount("100"),
+		Ratio:        junyousdk.MustParseRatio("1"),
+		Level1OpenId: "04a7bb30587780d34fd7916664b13651ee4a05dc8079c34a69e9cea2cc59faf7",
+		Level1Ratio:  junyousdk.MustParseRatio("0.7"),
+		Level2OpenId: "d92067abdbb2e2b68a4ad31597e45c1944389c0b26324233a4498a9066037369",
+		Level2Ratio:  junyousdk.MustParseRatio("0.3"),
+	}, signer)
+	if err != nil {
+		var flowErr *junyousdk.FlowError
+		if errors.As(err, &flowErr) {
+			log.Printf("权证释放在 %s 步骤失败（biz_no: %s）: %v\n", flowErr.Step, flowErr.BizNo, flowErr.Err)
+			return
+		}
+		log.Printf("权证释放失败: %v\n", err)
+		return
+	}
+
+	fmt.Printf("权证释放完成，biz_no: %s，tx_hash: %s\n", report.BizNo, report.Commit.Data.TxHash)
+}
@@ -0,0 +1,425 @@
+package junyousdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// FlowStep 业务流程步骤
+type FlowStep string
+
+const (
+	// FlowStepLogin 换取 Open Token
+	FlowStepLogin FlowStep = "login"
+	// FlowStepPreSubmit 预提交
+	FlowStepPreSubmit FlowStep = "pre_submit"
+	// FlowStepSign 对预提交 message 签名
+	FlowStepSign FlowStep = "sign"
+	// FlowStepCommit 提交上链
+	FlowStepCommit FlowStep = "commit"
+	// FlowStepConfirm 确认释放（仅权证合伙人释放）
+	FlowStepConfirm FlowStep = "confirm"
+)
+
+// FlowError 业务流程错误，标明失败的步骤；可用 errors.Is / errors.As 继续判断底层错误（如 *APIError）
+type FlowError struct {
+	// Step 失败的步骤
+	Step FlowStep
+	// BizNo 失败时已取得的业务单号，预提交成功前为空
+	BizNo string
+	// Err 底层错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *FlowError) Error() string {
+	if e.BizNo != "" {
+		return fmt.Sprintf("%s step failed (biz_no %s): %v", e.Step, e.BizNo, e.Err)
+	}
+	return fmt.Sprintf("%s step failed: %v", e.Step, e.Err)
+}
+
+// Unwrap 返回底层错误
+func (e *FlowError) Unwrap() error {
+	return e.Err
+}
+
+// GOCRewardReport RewardGOCFlow 各步骤的结果；流程失败时只填充已完成的步骤
+type GOCRewardReport struct {
+	// OperationId 配置了 Config.Journal 时的日志操作 ID
+	OperationId string
+	// OpenId 收款方 OpenId
+	OpenId string
+	// Amount 奖励金额
+	Amount Amount
+	// OpenAuth 本次预提交使用的 Open Token
+	OpenAuth string
+	// BizNo 预提交返回的业务单号
+	BizNo string
+	// PreSubmit 预提交返回的链上消息
+	PreSubmit *GOCRewardMessage
+	// Message 待签名并提交的 message，与预提交 data 逐字节一致
+	Message string
+	// PublicKey 签名公钥（未压缩十六进制）
+	PublicKey string
+	// DerHex DER 签名十六进制
+	DerHex string
+	// Commit 提交上链的结果
+	Commit *Result[CommitReceipt]
+	// CompletedSteps 已完成的步骤，按执行顺序
+	CompletedSteps []FlowStep
+	// Replayed 为 true 表示由幂等记录返回的此前结果，本次未重新预提交；此时只填充 BizNo 与 Commit
+	Replayed bool
+}
+
+// LogValue 实现 slog.LogValuer，隐藏 Open Token 与签名
+func (r *GOCRewardReport) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("operation_id", r.OperationId),
+		slog.String("open_id", r.OpenId),
+		slog.String("amount", r.Amount.String()),
+		slog.String("open_auth", redacted),
+		slog.String("biz_no", r.BizNo),
+		slog.String("public_key", r.PublicKey),
+		slog.String("der_hex", redacted),
+		slog.Any("completed_steps", r.CompletedSteps),
+		slog.Bool("replayed", r.Replayed),
+	)
+}
+
+// RewardGOCFlow 一次完成 GOC 奖励发放：
+// AuthLogin 为收款方换取新 Open Token → PreRewardGOC 预提交 → 以预提交 data 原始字节作为 message 调用 signer 签名 → RewardGOC 提交上链。
+// 每次调用都经 Client.Tokens 重新登录，保证每次预提交使用新 Token。
+// 开启 Config.VerifyPreSubmit 时，签名前以 VerifyPreReward 校验预提交消息，收款地址取自 Config.AddressResolver；
+// 此时 Config.EnterpriseAddress、Config.GOCRewardBizType、Config.AddressResolver 须已设置，
+// 否则不发出请求，返回 Step 为 pre_submit、包装 ErrPreSubmitExpectationMissing 的 *FlowError。
+// 任一步骤失败时返回 *FlowError（Step 为失败步骤）及已完成步骤的报告。
+// 配置了 Config.Journal 时记录各阶段，进程中断后可由 Resume 继续提交或放弃。
+func (s *APIService) RewardGOCFlow(ctx context.Context, openId string, amount Amount, signer Signer) (*GOCRewardReport, error) {
+	return s.rewardGOCFlow(ctx, openId, amount, signer, flowOptions{})
+}
+
+// rewardGOCFlow RewardGOCFlow 的实现，opts 供幂等调用使用
+func (s *APIService) rewardGOCFlow(ctx context.Context, openId string, amount Amount, signer Signer, opts flowOptions) (*GOCRewardReport, error) {
+	report := &GOCRewardReport{OpenId: openId, Amount: amount}
+	if strings.TrimSpace(openId) == "" {
+		return report, &FlowError{Step: FlowStepLogin, Err: errors.New("open_id is required")}
+	}
+	if signer == nil {
+		return report, &FlowError{Step: FlowStepSign, Err: errors.New("signer is required")}
+	}
+	if err := (PreGOCRewardRequest{Amount: amount}).Validate(); err != nil {
+		return report, &FlowError{Step: FlowStepPreSubmit, Err: err}
+	}
+	recipient, err := s.preSubmitRecipient(ctx, "Config.GOCRewardBizType", s.client.config.GOCRewardBizType, openId)
+	if err != nil {
+		return report, &FlowError{Step: FlowStepPreSubmit, Err: err}
+	}
+
+	journal, err := s.newOperationJournal(OperationGOCReward, opts.operationId)
+	if err != nil {
+		return report, &FlowError{Step: FlowStepLogin, Err: err}
+	}
+	report.OperationId = journal.id
+	if err := journal.record(ctx, JournalEntry{Phase: PhaseStarted, OpenId: openId, Amount: amount.String(), IdempotencyKey: opts.idempotencyKey}); err != nil {
+		return report, &FlowError{Step: FlowStepLogin, Err: err}
+	}
+
+	// 1. 换取新 Open Token
+	report.OpenAuth, err = s.client.tokens.FreshToken(ctx, openId)
+	if err != nil {
+		journal.fail(ctx, "", err)
+		return report, &FlowError{Step: FlowStepLogin, Err: err}
+	}
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepLogin)
+
+	// 2. 预提交
+	pre, err := s.PreRewardGOCMessageContext(ctx, PreGOCRewardRequest{Amount: amount}, report.OpenAuth)
+	if err = flowResultError(pre, err); err != nil {
+		journal.fail(ctx, "", err)
+		return report, &FlowError{Step: FlowStepPreSubmit, Err: err}
+	}
+	if pre.Data.BizNo == "" {
+		err = errors.New("pre-submit response has no biz_no")
+		journal.fail(ctx, "", err)
+		return report, &FlowError{Step: FlowStepPreSubmit, Err: err}
+	}
+	report.BizNo = pre.Data.BizNo
+	report.PreSubmit = &pre.Data
+	report.Message, err = PreSubmitMessage(pre)
+	if err != nil {
+		journal.fail(ctx, report.BizNo, err)
+		return report, &FlowError{Step: FlowStepPreSubmit, BizNo: report.BizNo, Err: err}
+	}
+	if s.client.config.VerifyPreSubmit {
+		if _, err := s.VerifyPreReward(PreGOCRewardRequest{Amount: amount}, recipient, []byte(report.Message)); err != nil {
+			journal.fail(ctx, report.BizNo, err)
+			return report, &FlowError{Step: FlowStepPreSubmit, BizNo: report.BizNo, Err: err}
+		}
+	}
+	if err := journal.record(ctx, JournalEntry{Phase: PhasePreSubmitted, BizNo: report.BizNo, Message: report.Message}); err != nil {
+		return report, &FlowError{Step: FlowStepPreSubmit, BizNo: report.BizNo, Err: err}
+	}
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepPreSubmit)
+
+	// 3. 签名
+	report.PublicKey, report.DerHex, err = signer.Sign([]byte(report.Message))
+	if err != nil {
+		journal.fail(ctx, report.BizNo, err)
+		return report, &FlowError{Step: FlowStepSign, BizNo: report.BizNo, Err: err}
+	}
+	if err := journal.record(ctx, JournalEntry{Phase: PhaseSigned, PublicKey: report.PublicKey, DerHex: report.DerHex}); err != nil {
+		return report, &FlowError{Step: FlowStepSign, BizNo: report.BizNo, Err: err}
+	}
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepSign)
+	if err := opts.runBeforeCommit(ctx, report.BizNo); err != nil {
+		journal.fail(ctx, report.BizNo, err)
+		return report, &FlowError{Step: FlowStepSign, BizNo: report.BizNo, Err: err}
+	}
+
+	// 4. 提交上链
+	commit, err := s.commitOperation(ctx, OperationGOCReward, report.BizNo, report.Message, report.PublicKey, report.DerHex)
+	report.Commit = commit
+	if err = flowResultError(commit, err); err != nil {
+		journal.commitFailed(ctx, err)
+		return report, &FlowError{Step: FlowStepCommit, BizNo: report.BizNo, Err: err}
+	}
+	journal.recordBestEffort(ctx, JournalEntry{Phase: PhaseCommitted, TxHash: commit.Data.TxHash})
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepCommit)
+
+	return report, nil
+}
+
+// EWTReleaseReport ReleaseEWTByPartner 各阶段的结果；流程失败时只填充已完成的步骤
+type EWTReleaseReport struct {
+	// OperationId 配置了 Config.Journal 时的日志操作 ID
+	OperationId string
+	// ReceiverOpenId 接收权证释放的用户 OpenId
+	ReceiverOpenId string
+	// Request 预提交请求
+	Request PreEWTReleaseByPartnerRequest
+	// OpenAuth 本次预提交使用的 Open Token
+	OpenAuth string
+	// BizNo 预提交返回的业务单号，贯穿提交与确认
+	BizNo string
+	// PreSubmit 预提交返回的链上消息
+	PreSubmit *EWTReleaseMessage
+	// Message 待签名并提交的 message，与预提交 data 逐字节一致
+	Message string
+	// PublicKey 签名公钥（未压缩十六进制）
+	PublicKey string
+	// DerHex DER 签名十六进制
+	DerHex string
+	// Commit 提交上链的结果
+	Commit *Result[CommitReceipt]
+	// Confirm 确认释放的结果
+	Confirm *Result[string]
+	// CompletedSteps 已完成的步骤，按执行顺序
+	CompletedSteps []FlowStep
+	// Replayed 为 true 表示由幂等记录返回的此前结果，本次未重新预提交；此时只填充 BizNo 与 Commit
+	Replayed bool
+}
+
+// Committed 是否已提交上链；为 true 而 Confirmed 为 false 时只需对 BizNo 重试 ConfirmEWTReleaseByPartner
+func (r *EWTReleaseReport) Committed() bool {
+	return containsStep(r.CompletedSteps, FlowStepCommit)
+}
+
+// Confirmed 是否已确认释放
+func (r *EWTReleaseReport) Confirmed() bool {
+	return containsStep(r.CompletedSteps, FlowStepConfirm)
+}
+
+// LogValue 实现 slog.LogValuer，隐藏 Open Token 与签名
+func (r *EWTReleaseReport) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("operation_id", r.OperationId),
+		slog.String("receiver_open_id", r.ReceiverOpenId),
+		slog.String("amount", r.Request.Amount.String()),
+		slog.String("open_auth", redacted),
+		slog.String("biz_no", r.BizNo),
+		slog.String("public_key", r.PublicKey),
+		slog.String("der_hex", redacted),
+		slog.Any("completed_steps", r.CompletedSteps),
+		slog.Bool("replayed", r.Replayed),
+	)
+}
+
+// ReleaseEWTByPartner 一次完成权证合伙人释放：
+// AuthLogin 为接收方换取新 Open Token → PreCommitEWTReleaseByPartner 预提交 → 对预提交 data 原始字节签名 →
+// CommitEWTReleaseByPartner 提交 → 以预提交返回的 biz_no 调用 ConfirmEWTReleaseByPartner 确认；
+// 开启 Config.VerifyPreSubmit 时，签名前以 VerifyPreEWTRelease 校验预提交消息，收款地址取自 Config.AddressResolver；
+// 此时 Config.EnterpriseAddress、Config.EWTReleaseBizType、Config.AddressResolver 须已设置，规则同 RewardGOCFlow。
+// 任一步骤失败时返回 *FlowError（Step 为失败步骤）及已完成步骤的报告；确认失败时提交已完成，可用 report.BizNo 单独重试确认。
+// 配置了 Config.Journal 时记录各阶段，进程中断后可由 Resume 继续提交、确认或放弃。
+func (s *APIService) ReleaseEWTByPartner(ctx context.Context, receiverOpenId string, req PreEWTReleaseByPartnerRequest, signer Signer) (*EWTReleaseReport, error) {
+	return s.releaseEWTByPartner(ctx, receiverOpenId, req, signer, flowOptions{})
+}
+
+// releaseEWTByPartner ReleaseEWTByPartner 的实现，opts 供幂等调用使用
+func (s *APIService) releaseEWTByPartner(ctx context.Context, receiverOpenId string, req PreEWTReleaseByPartnerRequest, signer Signer, opts flowOptions) (*EWTReleaseReport, error) {
+	report := &EWTReleaseReport{ReceiverOpenId: receiverOpenId, Request: req}
+	if strings.TrimSpace(receiverOpenId) == "" {
+		return report, &FlowError{Step: FlowStepLogin, Err: errors.New("receiver open_id is required")}
+	}
+	if signer == nil {
+		return report, &FlowError{Step: FlowStepSign, Err: errors.New("signer is required")}
+	}
+	if err := req.Validate(); err != nil {
+		return report, &FlowError{Step: FlowStepPreSubmit, Err: err}
+	}
+	recipient, err := s.preSubmitRecipient(ctx, "Config.EWTReleaseBizType", s.client.config.EWTReleaseBizType, receiverOpenId)
+	if err != nil {
+		return report, &FlowError{Step: FlowStepPreSubmit, Err: err}
+	}
+
+	journal, err := s.newOperationJournal(OperationEWTReleaseByPartner, opts.operationId)
+	if err != nil {
+		return report, &FlowError{Step: FlowStepLogin, Err: err}
+	}
+	report.OperationId = journal.id
+	if err := journal.record(ctx, JournalEntry{Phase: PhaseStarted, OpenId: receiverOpenId, Request: &req, IdempotencyKey: opts.idempotencyKey}); err != nil {
+		return report, &FlowError{Step: FlowStepLogin, Err: err}
+	}
+
+	// 1. 换取新 Open Token
+	report.OpenAuth, err = s.client.tokens.FreshToken(ctx, receiverOpenId)
+	if err != nil {
+		journal.fail(ctx, "", err)
+		return report, &FlowError{Step: FlowStepLogin, Err: err}
+	}
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepLogin)
+
+	// 2. 预提交
+	pre, err := s.PreCommitEWTReleaseByPartnerMessageContext(ctx, req, report.OpenAuth)
+	if err = flowResultError(pre, err); err != nil {
+		journal.fail(ctx, "", err)
+		return report, &FlowError{Step: FlowStepPreSubmit, Err: err}
+	}
+	if pre.Data.BizNo == "" {
+		err = errors.New("pre-submit response has no biz_no")
+		journal.fail(ctx, "", err)
+		return report, &FlowError{Step: FlowStepPreSubmit, Err: err}
+	}
+	report.BizNo = pre.Data.BizNo
+	report.PreSubmit = &pre.Data
+	report.Message, err = PreSubmitMessage(pre)
+	if err != nil {
+		journal.fail(ctx, report.BizNo, err)
+		return report, &FlowError{Step: FlowStepPreSubmit, BizNo: report.BizNo, Err: err}
+	}
+	if s.client.config.VerifyPreSubmit {
+		if _, err := s.VerifyPreEWTRelease(req, recipient, []byte(report.Message)); err != nil {
+			journal.fail(ctx, report.BizNo, err)
+			return report, &FlowError{Step: FlowStepPreSubmit, BizNo: report.BizNo, Err: err}
+		}
+	}
+	if err := journal.record(ctx, JournalEntry{Phase: PhasePreSubmitted, BizNo: report.BizNo, Message: report.Message}); err != nil {
+		return report, &FlowError{Step: FlowStepPreSubmit, BizNo: report.BizNo, Err: err}
+	}
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepPreSubmit)
+
+	// 3. 签名
+	report.PublicKey, report.DerHex, err = signer.Sign([]byte(report.Message))
+	if err != nil {
+		journal.fail(ctx, report.BizNo, err)
+		return report, &FlowError{Step: FlowStepSign, BizNo: report.BizNo, Err: err}
+	}
+	if err := journal.record(ctx, JournalEntry{Phase: PhaseSigned, PublicKey: report.PublicKey, DerHex: report.DerHex}); err != nil {
+		return report, &FlowError{Step: FlowStepSign, BizNo: report.BizNo, Err: err}
+	}
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepSign)
+	if err := opts.runBeforeCommit(ctx, report.BizNo); err != nil {
+		journal.fail(ctx, report.BizNo, err)
+		return report, &FlowError{Step: FlowStepSign, BizNo: report.BizNo, Err: err}
+	}
+
+	// 4. 提交
+	commit, err := s.commitOperation(ctx, OperationEWTReleaseByPartner, report.BizNo, report.Message, report.PublicKey, report.DerHex)
+	report.Commit = commit
+	if err = flowResultError(commit, err); err != nil {
+		journal.commitFailed(ctx, err)
+		return report, &FlowError{Step: FlowStepCommit, BizNo: report.BizNo, Err: err}
+	}
+	journal.recordBestEffort(ctx, JournalEntry{Phase: PhaseCommitted, TxHash: commit.Data.TxHash})
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepCommit)
+
+	// 5. 确认释放
+	confirm, err := s.ConfirmEWTReleaseByPartnerContext(ctx, EWTBizNoInfo{EWTBizNo: report.BizNo})
+	report.Confirm = confirm
+	if err = flowResultError(confirm, err); err != nil {
+		journal.recordBestEffort(ctx, JournalEntry{Phase: PhaseCommitted, Error: err.Error()})
+		return report, &FlowError{Step: FlowStepConfirm, BizNo: report.BizNo, Err: err}
+	}
+	journal.recordBestEffort(ctx, JournalEntry{Phase: PhaseConfirmed})
+	report.CompletedSteps = append(report.CompletedSteps, FlowStepConfirm)
+
+	return report, nil
+}
+
+// flowOptions 幂等调用传给流程的选项；零值即普通流程
+type flowOptions struct {
+	// operationId 预先生成的日志操作 ID，为空时由流程生成
+	operationId string
+	// idempotencyKey 写入日志 started 条目，供 Reconcile 关联幂等记录
+	idempotencyKey string
+	// beforeCommit 签名后、提交前调用；返回错误时中断流程，不提交
+	beforeCommit func(ctx context.Context, bizNo string) error
+}
+
+// runBeforeCommit 调用 beforeCommit，未设置时返回 nil
+func (o flowOptions) runBeforeCommit(ctx context.Context, bizNo string) error {
+	if o.beforeCommit == nil {
+		return nil
+	}
+	return o.beforeCommit(ctx, bizNo)
+}
+
+// commitOperation 按操作类型调用 RewardGOC 或 CommitEWTReleaseByPartner
+func (s *APIService) commitOperation(ctx context.Context, kind OperationKind, bizNo, message, publicKey, derHex string) (*Result[CommitReceipt], error) {
+	switch kind {
+	case OperationGOCReward:
+		return s.RewardGOCReceiptContext(ctx, CommitGOCRewardRequest{
+			BizNo:     bizNo,
+			Message:   message,
+			PublicKey: publicKey,
+			DerHex:    derHex,
+		})
+	case OperationEWTReleaseByPartner:
+		return s.CommitEWTReleaseByPartnerReceiptContext(ctx, CommitEWTReleaseByPartnerRequest{
+			BizNo:     bizNo,
+			Message:   message,
+			PublicKey: publicKey,
+			DerHex:    derHex,
+		})
+	default:
+		return nil, fmt.Errorf("unknown operation kind %q", kind)
+	}
+}
+
+// containsStep 判断 steps 是否包含 step
+func containsStep(steps []FlowStep, step FlowStep) bool {
+	for _, s := range steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// flowResultError 返回请求错误；无错误但 Success 为 false 时以 Message 构造错误
+func flowResultError[T any](result *Result[T], err error) error {
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return errors.New("empty result")
+	}
+	if !result.Success {
+		return fmt.Errorf("request failed: %s", result.Message)
+	}
+	return nil
+}
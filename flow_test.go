@@ -0,0 +1,361 @@
+package junyousdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// newFlowClient 返回指向 srv、提交前本地校验签名的客户端
+func newFlowClient(t *testing.T, srv *junyoutest.Server, config *junyousdk.Config) *junyousdk.Client {
+	t.Helper()
+	srv.SetSignatureVerifier(junyousdk.VerifyChainSignature)
+	client, err := junyousdk.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// newTestSigner 生成随机私钥的 LocalSigner
+func newTestSigner(t *testing.T) *junyousdk.LocalSigner {
+	t.Helper()
+	signer, err := junyousdk.GenerateLocalSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestRewardGOCFlow(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newFlowClient(t, srv, srv.Config())
+	openId := srv.AddUser("13800138000")
+	signer := newTestSigner(t)
+
+	report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("2.5"), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []junyousdk.FlowStep{junyousdk.FlowStepLogin, junyousdk.FlowStepPreSubmit, junyousdk.FlowStepSign, junyousdk.FlowStepCommit}
+	if !equalSteps(report.CompletedSteps, want) {
+		t.Fatalf("CompletedSteps = %v, want %v", report.CompletedSteps, want)
+	}
+	if report.Message != string(srv.PreSubmitMessage(report.BizNo)) {
+		t.Fatalf("Message = %q, want pre-submit bytes %q", report.Message, srv.PreSubmitMessage(report.BizNo))
+	}
+	if report.PreSubmit == nil || report.PreSubmit.To != srv.UserAddress(openId) {
+		t.Fatalf("PreSubmit = %+v, want to = %s", report.PreSubmit, srv.UserAddress(openId))
+	}
+	if !srv.Committed(report.BizNo) || report.Commit == nil || report.Commit.Data.TxHash == "" {
+		t.Fatalf("commit = %+v, want committed with tx_hash", report.Commit)
+	}
+	if got := srv.GOCBalance(openId); got != "2.5" {
+		t.Fatalf("GOCBalance = %s, want 2.5", got)
+	}
+
+	// 每次调用重新登录，使用新 Open Token
+	second, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("2.5"), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.OpenAuth == report.OpenAuth || second.BizNo == report.BizNo {
+		t.Fatalf("second run reused open_auth or biz_no: %s / %s", second.OpenAuth, second.BizNo)
+	}
+	if got := srv.GOCBalance(openId); got != "5" {
+		t.Fatalf("GOCBalance = %s, want 5", got)
+	}
+}
+
+func TestRewardGOCFlowPreSubmitFailure(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newFlowClient(t, srv, srv.Config())
+	openId := srv.AddUser("13800138000")
+	if err := srv.SetEnterpriseGOCBalance("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("2.5"), newTestSigner(t))
+	var flowErr *junyousdk.FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepPreSubmit {
+		t.Fatalf("err = %v, want pre_submit FlowError", err)
+	}
+	if !errors.Is(err, junyousdk.ErrInsufficientBalance) {
+		t.Fatalf("err = %v, want ErrInsufficientBalance", err)
+	}
+	if !equalSteps(report.CompletedSteps, []junyousdk.FlowStep{junyousdk.FlowStepLogin}) {
+		t.Fatalf("CompletedSteps = %v, want [login]", report.CompletedSteps)
+	}
+}
+
+func TestRewardGOCFlowRejectsUnexpectedRecipient(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	config := srv.Config().WithAddressResolver(func(context.Context, string) (string, error) {
+		return "0x0000000000000000000000000000000000000001", nil
+	})
+	client := newFlowClient(t, srv, config)
+	openId := srv.AddUser("13800138000")
+
+	signed := false
+	signer := junyousdk.SignerFunc(func([]byte) (string, string, error) {
+		signed = true
+		return "", "", errors.New("unexpected sign")
+	})
+	report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("1"), signer)
+	var flowErr *junyousdk.FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepPreSubmit || flowErr.BizNo == "" {
+		t.Fatalf("err = %v, want pre_submit FlowError with biz_no", err)
+	}
+	if signed || srv.Committed(report.BizNo) {
+		t.Fatal("message with unexpected recipient was signed or committed")
+	}
+}
+
+func TestRewardGOCFlowSignFailure(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newFlowClient(t, srv, srv.Config())
+	openId := srv.AddUser("13800138000")
+
+	signErr := errors.New("kms unavailable")
+	signer := junyousdk.SignerFunc(func([]byte) (string, string, error) {
+		return "", "", signErr
+	})
+	report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("1"), signer)
+	var flowErr *junyousdk.FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepSign || !errors.Is(err, signErr) {
+		t.Fatalf("err = %v, want sign FlowError wrapping %v", err, signErr)
+	}
+	if flowErr.BizNo != report.BizNo || report.BizNo == "" {
+		t.Fatalf("FlowError.BizNo = %q, report.BizNo = %q", flowErr.BizNo, report.BizNo)
+	}
+	if srv.Committed(report.BizNo) {
+		t.Fatal("unsigned operation committed")
+	}
+}
+
+func TestRewardGOCFlowValidation(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newFlowClient(t, srv, srv.Config())
+	openId := srv.AddUser("13800138000")
+	signer := newTestSigner(t)
+
+	tests := []struct {
+		name   string
+		openId string
+		amount junyousdk.Amount
+		signer junyousdk.Signer
+		step   junyousdk.FlowStep
+	}{
+		{"empty open_id", "", junyousdk.MustParseAmount("1"), signer, junyousdk.FlowStepLogin},
+		{"nil signer", openId, junyousdk.MustParseAmount("1"), nil, junyousdk.FlowStepSign},
+		{"zero amount", openId, junyousdk.Amount{}, signer, junyousdk.FlowStepPreSubmit},
+		{"unknown open_id", "unknown", junyousdk.MustParseAmount("1"), signer, junyousdk.FlowStepPreSubmit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.API().RewardGOCFlow(context.Background(), tt.openId, tt.amount, tt.signer)
+			var flowErr *junyousdk.FlowError
+			if !errors.As(err, &flowErr) || flowErr.Step != tt.step {
+				t.Fatalf("err = %v, want %s FlowError", err, tt.step)
+			}
+		})
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want none", n)
+	}
+}
+
+func TestReleaseEWTByPartner(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newFlowClient(t, srv, srv.Config())
+	receiver := srv.AddUser("13800138000")
+	level1 := srv.AddUser("13800138001")
+
+	req := junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount:       junyousdk.MustParseAmount("100"),
+		Ratio:        junyousdk.MustParseRatio("0.5"),
+		Level1OpenId: level1,
+		Level1Ratio:  junyousdk.MustParseRatio("0.1"),
+	}
+	report, err := client.API().ReleaseEWTByPartner(context.Background(), receiver, req, newTestSigner(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []junyousdk.FlowStep{
+		junyousdk.FlowStepLogin, junyousdk.FlowStepPreSubmit, junyousdk.FlowStepSign,
+		junyousdk.FlowStepCommit, junyousdk.FlowStepConfirm,
+	}
+	if !equalSteps(report.CompletedSteps, want) {
+		t.Fatalf("CompletedSteps = %v, want %v", report.CompletedSteps, want)
+	}
+	if !report.Committed() || !report.Confirmed() {
+		t.Fatalf("Committed = %v, Confirmed = %v, want both true", report.Committed(), report.Confirmed())
+	}
+	if report.Message != string(srv.PreSubmitMessage(report.BizNo)) {
+		t.Fatalf("Message = %q, want pre-submit bytes", report.Message)
+	}
+	if report.Confirm == nil || !report.Confirm.Success {
+		t.Fatalf("Confirm = %+v, want success", report.Confirm)
+	}
+	if got := srv.EWTBalance(receiver); got != "40" {
+		t.Fatalf("receiver EWTBalance = %s, want 40", got)
+	}
+	if got := srv.EWTBalance(level1); got != "10" {
+		t.Fatalf("level1 EWTBalance = %s, want 10", got)
+	}
+}
+
+func TestReleaseEWTByPartnerConfirmFailure(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newFlowClient(t, srv, srv.Config().WithRetryPolicy(fastRetryPolicy()))
+	receiver := srv.AddUser("13800138000")
+	srv.FailNext(junyousdk.APIPathEWTConfirmReleaseByPartner, http.StatusBadRequest)
+
+	req := junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount: junyousdk.MustParseAmount("100"),
+		Ratio:  junyousdk.MustParseRatio("0.5"),
+	}
+	report, err := client.API().ReleaseEWTByPartner(context.Background(), receiver, req, newTestSigner(t))
+	var flowErr *junyousdk.FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepConfirm || flowErr.BizNo != report.BizNo {
+		t.Fatalf("err = %v, want confirm FlowError with biz_no", err)
+	}
+	if !report.Committed() || report.Confirmed() {
+		t.Fatalf("Committed = %v, Confirmed = %v, want committed only", report.Committed(), report.Confirmed())
+	}
+	if got := srv.EWTBalance(receiver); got != "0" {
+		t.Fatalf("EWTBalance before confirm = %s, want 0", got)
+	}
+
+	// 提交已完成，只需对 BizNo 重试确认
+	confirm, err := client.API().ConfirmEWTReleaseByPartnerContext(context.Background(), junyousdk.EWTBizNoInfo{EWTBizNo: report.BizNo})
+	if err != nil || !confirm.Success {
+		t.Fatalf("retry confirm: %+v, %v", confirm, err)
+	}
+	if got := srv.EWTBalance(receiver); got != "50" {
+		t.Fatalf("EWTBalance after confirm = %s, want 50", got)
+	}
+}
+
+func TestReleaseEWTByPartnerValidation(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newFlowClient(t, srv, srv.Config())
+	receiver := srv.AddUser("13800138000")
+	signer := newTestSigner(t)
+	valid := junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount: junyousdk.MustParseAmount("100"),
+		Ratio:  junyousdk.MustParseRatio("0.5"),
+	}
+
+	tests := []struct {
+		name     string
+		receiver string
+		req      junyousdk.PreEWTReleaseByPartnerRequest
+		signer   junyousdk.Signer
+		step     junyousdk.FlowStep
+	}{
+		{"empty receiver", "", valid, signer, junyousdk.FlowStepLogin},
+		{"nil signer", receiver, valid, nil, junyousdk.FlowStepSign},
+		{"missing ratio", receiver, junyousdk.PreEWTReleaseByPartnerRequest{Amount: valid.Amount}, signer, junyousdk.FlowStepPreSubmit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.API().ReleaseEWTByPartner(context.Background(), tt.receiver, tt.req, tt.signer)
+			var flowErr *junyousdk.FlowError
+			if !errors.As(err, &flowErr) || flowErr.Step != tt.step {
+				t.Fatalf("err = %v, want %s FlowError", err, tt.step)
+			}
+		})
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want none", n)
+	}
+}
+
+// equalSteps 比较两个步骤序列
+func equalSteps(got, want []junyousdk.FlowStep) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFlowsVerifyPreSubmitOptIn(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	openId := srv.AddUser("13800138000")
+	release := junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount: junyousdk.MustParseAmount("100"),
+		Ratio:  junyousdk.MustParseRatio("0.5"),
+	}
+
+	tests := []struct {
+		name   string
+		config func() *junyousdk.Config
+	}{
+		{"no enterprise address", func() *junyousdk.Config { return srv.Config().WithEnterpriseAddress("") }},
+		{"no biz types", func() *junyousdk.Config { return srv.Config().WithBizTypes("", "") }},
+		{"no address resolver", func() *junyousdk.Config { return srv.Config().WithAddressResolver(nil) }},
+		{"empty recipient address", func() *junyousdk.Config {
+			return srv.Config().WithAddressResolver(func(context.Context, string) (string, error) { return "", nil })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFlowClient(t, srv, tt.config())
+			var flowErr *junyousdk.FlowError
+			_, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("1"), newTestSigner(t))
+			if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepPreSubmit || !errors.Is(err, junyousdk.ErrPreSubmitExpectationMissing) {
+				t.Fatalf("RewardGOCFlow err = %v, want pre_submit ErrPreSubmitExpectationMissing", err)
+			}
+			_, err = client.API().ReleaseEWTByPartner(context.Background(), openId, release, newTestSigner(t))
+			if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepPreSubmit || !errors.Is(err, junyousdk.ErrPreSubmitExpectationMissing) {
+				t.Fatalf("ReleaseEWTByPartner err = %v, want pre_submit ErrPreSubmitExpectationMissing", err)
+			}
+		})
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want none", n)
+	}
+}
+
+func TestFlowsSkipVerifyByDefault(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	openId := srv.AddUser("13800138000")
+	config := srv.Config().
+		WithVerifyPreSubmit(false).
+		WithEnterpriseAddress("").
+		WithBizTypes("", "").
+		WithAddressResolver(nil)
+	client := newFlowClient(t, srv, config)
+
+	report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("1"), newTestSigner(t))
+	if err != nil || !srv.Committed(report.BizNo) {
+		t.Fatalf("RewardGOCFlow = %+v, %v; want committed without pre-submit verification", report, err)
+	}
+	_, err = client.API().ReleaseEWTByPartner(context.Background(), openId, junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount: junyousdk.MustParseAmount("100"),
+		Ratio:  junyousdk.MustParseRatio("0.5"),
+	}, newTestSigner(t))
+	if err != nil {
+		t.Fatalf("ReleaseEWTByPartner err = %v, want nil without pre-submit verification", err)
+	}
+}
@@ -1,13 +1,14 @@
 package junyousdk
 
 import (
+	"context"
 	"net/http"
 )
 
 // PreGOCRewardRequest 对应 POST /api/open/v1/goc/pre_reward。
 // Body 仅 amount；收款方由 X-Open-Auth 解析。成功时 result.data 为待上链的 GOC 转账消息 JSON（通常含 from、to、amount、biz_no、biz_type、biz_desc 等），键名以响应为准。
 type PreGOCRewardRequest struct {
-	Amount string `json:"amount"` // 金额，>0 的十进制字符串
+	Amount Amount `json:"amount"` // 金额，须 > 0，序列化为十进制字符串
 }
 
 // CommitGOCRewardRequest 对应 POST /api/open/v1/goc/reward。
@@ -22,7 +23,15 @@ type CommitGOCRewardRequest struct {
 // PreRewardGOC GOC 预提交。成功时 Data 即待签名/待提交的链上业务消息。
 // openAuth 必填：收款方 Open Token（X-Open-Auth），须先对该用户 open_id 调用 AuthLogin；服务端要求每次预提交使用新的 Token。
 func (s *APIService) PreRewardGOC(req PreGOCRewardRequest, openAuth string) (*Result[map[string]any], error) {
-	return DoRequest[map[string]any](s.client,
+	return s.PreRewardGOCContext(context.Background(), req, openAuth)
+}
+
+// PreRewardGOCContext 同 PreRewardGOC，ctx 用于取消与超时控制
+func (s *APIService) PreRewardGOCContext(ctx context.Context, req PreGOCRewardRequest, openAuth string) (*Result[map[string]any], error) {
+	if err := req.Validate(); err != nil {
+		return NewParamErrorResult[map[string]any](err.Error()), err
+	}
+	return DoRequestContext[map[string]any](ctx, s.client,
 		http.MethodPost,
 		APIPathGOCPreReward,
 		req,
@@ -30,9 +39,83 @@ func (s *APIService) PreRewardGOC(req PreGOCRewardRequest, openAuth string) (*Re
 	)
 }
 
+// PreRewardGOCMessage 同 PreRewardGOC，Data 解析为 GOCRewardMessage
+func (s *APIService) PreRewardGOCMessage(req PreGOCRewardRequest, openAuth string) (*Result[GOCRewardMessage], error) {
+	return s.PreRewardGOCMessageContext(context.Background(), req, openAuth)
+}
+
+// PreRewardGOCMessageContext 同 PreRewardGOCMessage，ctx 用于取消与超时控制
+func (s *APIService) PreRewardGOCMessageContext(ctx context.Context, req PreGOCRewardRequest, openAuth string) (*Result[GOCRewardMessage], error) {
+	if err := req.Validate(); err != nil {
+		return NewParamErrorResult[GOCRewardMessage](err.Error()), err
+	}
+	return DoRequestContext[GOCRewardMessage](ctx, s.client,
+		http.MethodPost,
+		APIPathGOCPreReward,
+		req,
+		openAuthExtraHeaders(openAuth),
+	)
+}
+
+// PreRewardGOCByOpenId 同 PreRewardGOC，由 Client.Tokens 为 openId 换取新 Token 作为 X-Open-Auth
+func (s *APIService) PreRewardGOCByOpenId(req PreGOCRewardRequest, openId string) (*Result[map[string]any], error) {
+	return s.PreRewardGOCByOpenIdContext(context.Background(), req, openId)
+}
+
+// PreRewardGOCByOpenIdContext 同 PreRewardGOCByOpenId，ctx 用于取消与超时控制
+func (s *APIService) PreRewardGOCByOpenIdContext(ctx context.Context, req PreGOCRewardRequest, openId string) (*Result[map[string]any], error) {
+	if err := req.Validate(); err != nil {
+		return NewParamErrorResult[map[string]any](err.Error()), err
+	}
+	return callByOpenId(ctx, s, openId, TokenFresh, func(openAuth string) (*Result[map[string]any], error) {
+		return s.PreRewardGOCContext(ctx, req, openAuth)
+	})
+}
+
+// PreRewardGOCMessageByOpenId 同 PreRewardGOCMessage，由 Client.Tokens 为 openId 换取新 Token 作为 X-Open-Auth
+func (s *APIService) PreRewardGOCMessageByOpenId(req PreGOCRewardRequest, openId string) (*Result[GOCRewardMessage], error) {
+	return s.PreRewardGOCMessageByOpenIdContext(context.Background(), req, openId)
+}
+
+// PreRewardGOCMessageByOpenIdContext 同 PreRewardGOCMessageByOpenId，ctx 用于取消与超时控制
+func (s *APIService) PreRewardGOCMessageByOpenIdContext(ctx context.Context, req PreGOCRewardRequest, openId string) (*Result[GOCRewardMessage], error) {
+	if err := req.Validate(); err != nil {
+		return NewParamErrorResult[GOCRewardMessage](err.Error()), err
+	}
+	return callByOpenId(ctx, s, openId, TokenFresh, func(openAuth string) (*Result[GOCRewardMessage], error) {
+		return s.PreRewardGOCMessageContext(ctx, req, openAuth)
+	})
+}
+
 // RewardGOC GOC 提交上链（与 PreRewardGOC 对应）。不携带 X-Open-Auth。
 func (s *APIService) RewardGOC(req CommitGOCRewardRequest) (*Result[map[string]any], error) {
-	return DoRequest[map[string]any](s.client,
+	return s.RewardGOCContext(context.Background(), req)
+}
+
+// RewardGOCContext 同 RewardGOC，ctx 用于取消与超时控制
+func (s *APIService) RewardGOCContext(ctx context.Context, req CommitGOCRewardRequest) (*Result[map[string]any], error) {
+	if err := s.checkCommitSignature(req.Message, req.PublicKey, req.DerHex); err != nil {
+		return NewParamErrorResult[map[string]any](err.Error()), err
+	}
+	return DoRequestContext[map[string]any](ctx, s.client,
+		http.MethodPost,
+		APIPathGOCReward,
+		req,
+		nil,
+	)
+}
+
+// RewardGOCReceipt 同 RewardGOC，Data 解析为 CommitReceipt
+func (s *APIService) RewardGOCReceipt(req CommitGOCRewardRequest) (*Result[CommitReceipt], error) {
+	return s.RewardGOCReceiptContext(context.Background(), req)
+}
+
+// RewardGOCReceiptContext 同 RewardGOCReceipt，ctx 用于取消与超时控制
+func (s *APIService) RewardGOCReceiptContext(ctx context.Context, req CommitGOCRewardRequest) (*Result[CommitReceipt], error) {
+	if err := s.checkCommitSignature(req.Message, req.PublicKey, req.DerHex); err != nil {
+		return NewParamErrorResult[CommitReceipt](err.Error()), err
+	}
+	return DoRequestContext[CommitReceipt](ctx, s.client,
 		http.MethodPost,
 		APIPathGOCReward,
 		req,
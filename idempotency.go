@@ -0,0 +1,400 @@
+package junyousdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 幂等错误
+var (
+	// ErrIdempotencyStoreNotConfigured 未配置 Config.IdempotencyStore
+	ErrIdempotencyStoreNotConfigured = errors.New("junyousdk: idempotency store not configured")
+	// ErrIdempotencyInProgress 该幂等键的操作正在进行，或上次提交结果未知（需结合 Journal / Resume 处理）
+	ErrIdempotencyInProgress = errors.New("junyousdk: idempotent operation in progress or outcome unknown")
+	// ErrIdempotencyKeyMismatch 同一幂等键对应的请求参数不同
+	ErrIdempotencyKeyMismatch = errors.New("junyousdk: idempotency key reused with different parameters")
+)
+
+// IdempotencyStatus 幂等记录状态
+type IdempotencyStatus string
+
+const (
+	// IdempotencyPending 进行中，或已签名但提交结果未知
+	IdempotencyPending IdempotencyStatus = "pending"
+	// IdempotencyCommitted 已提交上链，权证释放尚未确认
+	IdempotencyCommitted IdempotencyStatus = "committed"
+	// IdempotencyCompleted 已完成：GOC 已提交上链，权证释放已确认
+	IdempotencyCompleted IdempotencyStatus = "completed"
+)
+
+// IdempotencyRecord 幂等键对应的操作记录
+type IdempotencyRecord struct {
+	Key         string            `json:"key"`
+	Kind        OperationKind     `json:"kind"`
+	Status      IdempotencyStatus `json:"status"`
+	Fingerprint string            `json:"fingerprint"` // 请求参数摘要，用于发现同一幂等键被不同参数复用
+	OperationId string            `json:"operation_id,omitempty"`
+	BizNo       string            `json:"biz_no,omitempty"`
+	TxHash      string            `json:"tx_hash,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// IdempotencyStore 幂等键存储，实现须并发安全；多实例部署时应使用共享存储（如数据库唯一索引、Redis SETNX）
+type IdempotencyStore interface {
+	// Reserve 原子地占用 key：不存在时写入 record 并返回 (record, true)；已存在时返回已有记录与 false
+	Reserve(ctx context.Context, key string, record IdempotencyRecord) (IdempotencyRecord, bool, error)
+	// Get 返回 key 的记录；不存在时返回 false
+	Get(ctx context.Context, key string) (IdempotencyRecord, bool, error)
+	// Update 更新 key 的记录
+	Update(ctx context.Context, key string, record IdempotencyRecord) error
+	// Delete 删除 key；操作确定未提交而失败时调用，允许之后用同一 key 重试
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryIdempotencyStore 进程内 IdempotencyStore，进程退出后丢失
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+// NewMemoryIdempotencyStore 创建进程内 IdempotencyStore
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{records: map[string]IdempotencyRecord{}}
+}
+
+// Reserve 实现 IdempotencyStore
+func (s *MemoryIdempotencyStore) Reserve(_ context.Context, key string, record IdempotencyRecord) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[key]; ok {
+		return existing, false, nil
+	}
+	s.records[key] = record
+	return record, true, nil
+}
+
+// Get 实现 IdempotencyStore
+func (s *MemoryIdempotencyStore) Get(_ context.Context, key string) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[key]
+	return record, ok, nil
+}
+
+// Update 实现 IdempotencyStore
+func (s *MemoryIdempotencyStore) Update(_ context.Context, key string, record IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = record
+	return nil
+}
+
+// Delete 实现 IdempotencyStore
+func (s *MemoryIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// IdempotencyError 幂等键已被占用时返回的错误，携带已有记录；可用 errors.Is 与 ErrIdempotencyInProgress / ErrIdempotencyKeyMismatch 比较
+type IdempotencyError struct {
+	// Record 已有记录
+	Record IdempotencyRecord
+	// Err ErrIdempotencyInProgress 或 ErrIdempotencyKeyMismatch
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *IdempotencyError) Error() string {
+	if e.Record.BizNo != "" {
+		return fmt.Sprintf("%v (key %s, biz_no %s)", e.Err, e.Record.Key, e.Record.BizNo)
+	}
+	return fmt.Sprintf("%v (key %s)", e.Err, e.Record.Key)
+}
+
+// Unwrap 返回底层错误
+func (e *IdempotencyError) Unwrap() error {
+	return e.Err
+}
+
+// RewardGOCOnce 以调用方业务键 idempotencyKey 幂等地执行 RewardGOCFlow（需配置 Config.IdempotencyStore）：
+//   - 首次调用执行完整流程；确定未提交的失败会释放该键，之后可用同一键重试；
+//   - 该键已完成时不再预提交，返回根据记录构造的报告（Replayed 为 true）；
+//   - 该键进行中或提交结果未知时返回 *IdempotencyError（ErrIdempotencyInProgress）；Resume 提交或放弃该操作后，
+//     由 Reconcile 按日志结果更新记录；
+//   - 同一键的 openId / amount 与记录不同时返回 *IdempotencyError（ErrIdempotencyKeyMismatch）；金额按数值比较，"1" 与 "1.0" 相同。
+//
+// 占用键时即写入日志操作 ID，提交前写入 biz_no，写入失败时不提交。
+func (s *APIService) RewardGOCOnce(ctx context.Context, idempotencyKey, openId string, amount Amount, signer Signer) (*GOCRewardReport, error) {
+	fingerprint := idempotencyFingerprint(OperationGOCReward, openId, amount.d.normalized())
+	record, err := s.reserveIdempotencyKey(ctx, idempotencyKey, OperationGOCReward, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if record.Status == IdempotencyCompleted {
+		return &GOCRewardReport{
+			OperationId:    record.OperationId,
+			OpenId:         openId,
+			Amount:         amount,
+			BizNo:          record.BizNo,
+			Commit:         replayedReceipt(record),
+			CompletedSteps: []FlowStep{FlowStepCommit},
+			Replayed:       true,
+		}, nil
+	}
+
+	report, err := s.rewardGOCFlow(ctx, openId, amount, signer, s.idempotentFlowOptions(record))
+	s.settleIdempotencyKey(ctx, record, report.BizNo, report.Commit, err, false)
+	return report, err
+}
+
+// ReleaseEWTByPartnerOnce 以调用方业务键 idempotencyKey 幂等地执行 ReleaseEWTByPartner（需配置 Config.IdempotencyStore），规则同 RewardGOCOnce；
+// 该键已提交但未确认时不再预提交，只补做确认。
+func (s *APIService) ReleaseEWTByPartnerOnce(ctx context.Context, idempotencyKey, receiverOpenId string, req PreEWTReleaseByPartnerRequest, signer Signer) (*EWTReleaseReport, error) {
+	fingerprint := idempotencyFingerprint(OperationEWTReleaseByPartner, receiverOpenId,
+		req.Amount.d.normalized(), req.Ratio.d.normalized(),
+		req.Level1OpenId, req.Level1Ratio.d.normalized(),
+		req.Level2OpenId, req.Level2Ratio.d.normalized())
+	record, err := s.reserveIdempotencyKey(ctx, idempotencyKey, OperationEWTReleaseByPartner, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	switch record.Status {
+	case IdempotencyCompleted:
+		return &EWTReleaseReport{
+			OperationId:    record.OperationId,
+			ReceiverOpenId: receiverOpenId,
+			Request:        req,
+			BizNo:          record.BizNo,
+			Commit:         replayedReceipt(record),
+			CompletedSteps: []FlowStep{FlowStepCommit, FlowStepConfirm},
+			Replayed:       true,
+		}, nil
+	case IdempotencyCommitted:
+		report := &EWTReleaseReport{
+			OperationId:    record.OperationId,
+			ReceiverOpenId: receiverOpenId,
+			Request:        req,
+			BizNo:          record.BizNo,
+			Commit:         replayedReceipt(record),
+			CompletedSteps: []FlowStep{FlowStepCommit},
+			Replayed:       true,
+		}
+		confirm, err := s.ConfirmEWTReleaseByPartnerContext(ctx, EWTBizNoInfo{EWTBizNo: record.BizNo})
+		report.Confirm = confirm
+		if err = flowResultError(confirm, err); err != nil {
+			return report, &FlowError{Step: FlowStepConfirm, BizNo: record.BizNo, Err: err}
+		}
+		report.CompletedSteps = append(report.CompletedSteps, FlowStepConfirm)
+		record.Status = IdempotencyCompleted
+		s.updateIdempotencyRecord(ctx, record)
+		return report, nil
+	}
+
+	report, err := s.releaseEWTByPartner(ctx, receiverOpenId, req, signer, s.idempotentFlowOptions(record))
+	s.settleIdempotencyKey(ctx, record, report.BizNo, report.Commit, err, true)
+	return report, err
+}
+
+// reserveIdempotencyKey 占用幂等键；返回的记录为新占用的 pending 记录（已带新的操作 ID），或可直接复用的已提交/已完成记录
+func (s *APIService) reserveIdempotencyKey(ctx context.Context, key string, kind OperationKind, fingerprint string) (IdempotencyRecord, error) {
+	store := s.client.config.IdempotencyStore
+	if store == nil {
+		return IdempotencyRecord{}, ErrIdempotencyStoreNotConfigured
+	}
+	if strings.TrimSpace(key) == "" {
+		return IdempotencyRecord{}, errors.New("idempotency key is required")
+	}
+
+	operationId, err := newOperationId()
+	if err != nil {
+		return IdempotencyRecord{}, err
+	}
+	now := s.client.config.Clock.Now()
+	record, reserved, err := store.Reserve(ctx, key, IdempotencyRecord{
+		Key:         key,
+		Kind:        kind,
+		Status:      IdempotencyPending,
+		Fingerprint: fingerprint,
+		OperationId: operationId,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return IdempotencyRecord{}, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if reserved {
+		return record, nil
+	}
+	if record.Kind != kind || record.Fingerprint != fingerprint {
+		return IdempotencyRecord{}, &IdempotencyError{Record: record, Err: ErrIdempotencyKeyMismatch}
+	}
+	if record.Status == IdempotencyPending {
+		return IdempotencyRecord{}, &IdempotencyError{Record: record, Err: ErrIdempotencyInProgress}
+	}
+	return record, nil
+}
+
+// idempotentFlowOptions 幂等调用的流程选项：沿用记录中的操作 ID，并在提交前将 biz_no 写入记录
+func (s *APIService) idempotentFlowOptions(record IdempotencyRecord) flowOptions {
+	return flowOptions{
+		operationId:    record.OperationId,
+		idempotencyKey: record.Key,
+		beforeCommit: func(ctx context.Context, bizNo string) error {
+			record.BizNo = bizNo
+			record.UpdatedAt = s.client.config.Clock.Now()
+			if err := s.client.config.IdempotencyStore.Update(ctx, record.Key, record); err != nil {
+				return fmt.Errorf("failed to update idempotency record: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// settleIdempotencyKey 按流程结果更新幂等记录：
+// 已提交则记为 committed / completed；确定未提交则删除该键以便重试；提交结果未知则保留 pending 及 biz_no
+func (s *APIService) settleIdempotencyKey(ctx context.Context, record IdempotencyRecord, bizNo string, commit *Result[CommitReceipt], flowErr error, needsConfirm bool) {
+	if bizNo != "" {
+		record.BizNo = bizNo
+	}
+	if commit != nil && commit.Success {
+		record.TxHash = commit.Data.TxHash
+	}
+
+	var fe *FlowError
+	switch {
+	case flowErr == nil:
+		record.Status = IdempotencyCompleted
+	case errors.As(flowErr, &fe) && fe.Step == FlowStepConfirm:
+		record.Status = IdempotencyCommitted
+	case errors.As(flowErr, &fe) && fe.Step == FlowStepCommit:
+		switch commitFailurePhase(fe.Err) {
+		case PhaseCommitted:
+			record.Status = IdempotencyCompleted
+			if needsConfirm {
+				record.Status = IdempotencyCommitted
+			}
+		case PhaseFailed:
+			s.deleteIdempotencyKey(ctx, record.Key)
+			return
+		default:
+			record.Status = IdempotencyPending
+		}
+	default:
+		// 提交之前失败，没有产生链上操作
+		s.deleteIdempotencyKey(ctx, record.Key)
+		return
+	}
+	s.updateIdempotencyRecord(ctx, record)
+}
+
+// Reconcile 按 Config.Journal 中的操作结果更新未完成的幂等记录（需同时配置 Config.Journal 与 Config.IdempotencyStore）：
+//   - 已提交：GOC 奖励记为 completed，权证释放记为 committed，已确认时记为 completed；
+//   - 已放弃或失败（确定未提交）：删除该键，之后可用同一键重试；
+//   - 其余阶段结果仍未知，记录保持不变，由 Resume 处理后再更新。
+//
+// 只更新操作 ID 与日志一致的记录，不影响删除后用同一键发起的新操作。Resume 处理完未完成操作后会自动调用。
+// 单个键更新失败不会中断其余键，全部错误合并返回。
+func Reconcile(ctx context.Context, client *Client) error {
+	config := client.config
+	if config.Journal == nil {
+		return ErrJournalNotConfigured
+	}
+	if config.IdempotencyStore == nil {
+		return ErrIdempotencyStoreNotConfigured
+	}
+	ops, err := JournalOperations(ctx, config.Journal)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, op := range ops {
+		if op.IdempotencyKey == "" {
+			continue
+		}
+		if err := client.API().reconcileIdempotencyKey(ctx, op); err != nil {
+			errs = append(errs, fmt.Errorf("reconcile idempotency key %s: %w", op.IdempotencyKey, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reconcileIdempotencyKey 按单个操作的日志结果更新其幂等记录
+func (s *APIService) reconcileIdempotencyKey(ctx context.Context, op Operation) error {
+	store := s.client.config.IdempotencyStore
+	record, ok, err := store.Get(ctx, op.IdempotencyKey)
+	if err != nil || !ok || record.OperationId != op.OperationId || record.Status == IdempotencyCompleted {
+		return err
+	}
+
+	status := record.Status
+	switch op.Phase {
+	case PhaseAbandoned, PhaseFailed:
+		if record.Status != IdempotencyPending {
+			return nil
+		}
+		return store.Delete(ctx, record.Key)
+	case PhaseCommitted:
+		status = IdempotencyCompleted
+		if op.Kind == OperationEWTReleaseByPartner {
+			status = IdempotencyCommitted
+		}
+	case PhaseConfirmed:
+		status = IdempotencyCompleted
+	}
+	if status == record.Status {
+		return nil
+	}
+	record.Status = status
+	if op.BizNo != "" {
+		record.BizNo = op.BizNo
+	}
+	if op.TxHash != "" {
+		record.TxHash = op.TxHash
+	}
+	record.UpdatedAt = s.client.config.Clock.Now()
+	return store.Update(ctx, record.Key, record)
+}
+
+// updateIdempotencyRecord 写入幂等记录；失败时记录保持 pending，重复调用会得到 ErrIdempotencyInProgress 而不会重复发放
+func (s *APIService) updateIdempotencyRecord(ctx context.Context, record IdempotencyRecord) {
+	record.UpdatedAt = s.client.config.Clock.Now()
+	if err := s.client.config.IdempotencyStore.Update(ctx, record.Key, record); err != nil {
+		s.logIdempotencyError(ctx, record.Key, err)
+	}
+}
+
+// deleteIdempotencyKey 释放幂等键；失败时该键保持 pending
+func (s *APIService) deleteIdempotencyKey(ctx context.Context, key string) {
+	if err := s.client.config.IdempotencyStore.Delete(ctx, key); err != nil {
+		s.logIdempotencyError(ctx, key, err)
+	}
+}
+
+func (s *APIService) logIdempotencyError(ctx context.Context, key string, err error) {
+	if logger := s.client.config.Logger; logger != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "junyousdk idempotency store write failed",
+			slog.String("key", key),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// idempotencyFingerprint 请求参数摘要
+func idempotencyFingerprint(kind OperationKind, parts ...string) string {
+	return string(kind) + "\n" + strings.Join(parts, "\n")
+}
+
+// replayedReceipt 由幂等记录构造提交回执
+func replayedReceipt(record IdempotencyRecord) *Result[CommitReceipt] {
+	return NewSuccessResult("success", CommitReceipt{BizNo: record.BizNo, TxHash: record.TxHash})
+}
@@ -0,0 +1,208 @@
+package junyousdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// failingUpdateStore Update 总是失败的 IdempotencyStore
+type failingUpdateStore struct {
+	*junyousdk.MemoryIdempotencyStore
+}
+
+func (s failingUpdateStore) Update(context.Context, string, junyousdk.IdempotencyRecord) error {
+	return errors.New("store unavailable")
+}
+
+func TestRewardGOCOnceReplays(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newFlowClient(t, srv, srv.Config().WithIdempotencyStore(junyousdk.NewMemoryIdempotencyStore()))
+	openId := srv.AddUser("13800138000")
+	signer := newTestSigner(t)
+
+	first, err := client.API().RewardGOCOnce(context.Background(), "event-1", openId, junyousdk.MustParseAmount("1"), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 数值相同的金额视为同一请求
+	second, err := client.API().RewardGOCOnce(context.Background(), "event-1", openId, junyousdk.MustParseAmount("1.0"), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.Replayed || second.BizNo != first.BizNo || second.Commit.Data.TxHash != first.Commit.Data.TxHash {
+		t.Fatalf("second = %+v, want replay of %s", second, first.BizNo)
+	}
+	if got := srv.GOCBalance(openId); got != "1" {
+		t.Fatalf("GOCBalance = %s, want 1", got)
+	}
+
+	_, err = client.API().RewardGOCOnce(context.Background(), "event-1", openId, junyousdk.MustParseAmount("2"), signer)
+	var idemErr *junyousdk.IdempotencyError
+	if !errors.As(err, &idemErr) || !errors.Is(err, junyousdk.ErrIdempotencyKeyMismatch) {
+		t.Fatalf("err = %v, want ErrIdempotencyKeyMismatch", err)
+	}
+}
+
+func TestRewardGOCOnceReleasesKeyBeforeCommitFailure(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	store := junyousdk.NewMemoryIdempotencyStore()
+	client := newFlowClient(t, srv, srv.Config().WithIdempotencyStore(store))
+	openId := srv.AddUser("13800138000")
+	if err := srv.SetEnterpriseGOCBalance("0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.API().RewardGOCOnce(context.Background(), "event-1", openId, junyousdk.MustParseAmount("1"), newTestSigner(t)); !errors.Is(err, junyousdk.ErrInsufficientBalance) {
+		t.Fatalf("err = %v, want ErrInsufficientBalance", err)
+	}
+	if _, ok, _ := store.Get(context.Background(), "event-1"); ok {
+		t.Fatal("key kept after failure before commit")
+	}
+}
+
+func TestRewardGOCOnceStoreFailureBlocksCommit(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	store := failingUpdateStore{junyousdk.NewMemoryIdempotencyStore()}
+	client := newFlowClient(t, srv, srv.Config().WithIdempotencyStore(store))
+	openId := srv.AddUser("13800138000")
+
+	report, err := client.API().RewardGOCOnce(context.Background(), "event-1", openId, junyousdk.MustParseAmount("1"), newTestSigner(t))
+	var flowErr *junyousdk.FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepSign {
+		t.Fatalf("err = %v, want sign FlowError", err)
+	}
+	if srv.Committed(report.BizNo) {
+		t.Fatal("committed although biz_no could not be recorded")
+	}
+}
+
+func TestIdempotencyKeySettledByResume(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	store := junyousdk.NewMemoryIdempotencyStore()
+	journal := junyousdk.NewMemoryJournal()
+	client := newFlowClient(t, srv, srv.Config().WithIdempotencyStore(store).WithJournal(journal))
+	openId := srv.AddUser("13800138000")
+	signer := newTestSigner(t)
+	srv.FailNext(junyousdk.APIPathGOCReward, http.StatusBadGateway)
+
+	// 提交结果未知：记录保持 pending，但已带操作 ID 与 biz_no
+	report, err := client.API().RewardGOCOnce(context.Background(), "event-1", openId, junyousdk.MustParseAmount("1"), signer)
+	var flowErr *junyousdk.FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepCommit {
+		t.Fatalf("err = %v, want commit FlowError", err)
+	}
+	record, ok, err := store.Get(context.Background(), "event-1")
+	if err != nil || !ok {
+		t.Fatalf("Get = %v, %v", ok, err)
+	}
+	if record.Status != junyousdk.IdempotencyPending || record.OperationId != report.OperationId || record.BizNo != report.BizNo {
+		t.Fatalf("record = %+v, want pending with operation %s and biz_no %s", record, report.OperationId, report.BizNo)
+	}
+	if _, err := client.API().RewardGOCOnce(context.Background(), "event-1", openId, junyousdk.MustParseAmount("1"), signer); !errors.Is(err, junyousdk.ErrIdempotencyInProgress) {
+		t.Fatalf("err = %v, want ErrIdempotencyInProgress", err)
+	}
+
+	if _, err := junyousdk.Resume(context.Background(), client, signer, junyousdk.ResumeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	record, _, _ = store.Get(context.Background(), "event-1")
+	if record.Status != junyousdk.IdempotencyCompleted || record.TxHash == "" {
+		t.Fatalf("record = %+v, want completed with tx_hash", record)
+	}
+	replay, err := client.API().RewardGOCOnce(context.Background(), "event-1", openId, junyousdk.MustParseAmount("1"), signer)
+	if err != nil || !replay.Replayed || replay.BizNo != report.BizNo {
+		t.Fatalf("replay = %+v, %v, want replay of %s", replay, err, report.BizNo)
+	}
+	if got := srv.GOCBalance(openId); got != "1" {
+		t.Fatalf("GOCBalance = %s, want 1", got)
+	}
+}
+
+func TestReconcileReleasesAbandonedKey(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	store := junyousdk.NewMemoryIdempotencyStore()
+	journal := junyousdk.NewMemoryJournal()
+	client := newFlowClient(t, srv, srv.Config().WithIdempotencyStore(store).WithJournal(journal))
+	ctx := context.Background()
+	now := time.Now()
+
+	// 进程在占用业务键并写入 started 后退出
+	if _, _, err := store.Reserve(ctx, "event-1", junyousdk.IdempotencyRecord{
+		Key: "event-1", Kind: junyousdk.OperationGOCReward, Status: junyousdk.IdempotencyPending, OperationId: "op1",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.Append(ctx, junyousdk.JournalEntry{
+		OperationId: "op1", Kind: junyousdk.OperationGOCReward, Phase: junyousdk.PhaseStarted, Time: now, IdempotencyKey: "event-1",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// event-2 的旧操作已失败，该键已被新操作占用，不应被释放
+	if err := journal.Append(ctx, junyousdk.JournalEntry{
+		OperationId: "op0", Kind: junyousdk.OperationGOCReward, Phase: junyousdk.PhaseFailed, Time: now.Add(-time.Minute), IdempotencyKey: "event-2",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Reserve(ctx, "event-2", junyousdk.IdempotencyRecord{
+		Key: "event-2", Kind: junyousdk.OperationGOCReward, Status: junyousdk.IdempotencyPending, OperationId: "op-new",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := junyousdk.Resume(ctx, client, nil, junyousdk.ResumeOptions{})
+	if err != nil || len(results) != 1 || results[0].Phase != junyousdk.PhaseAbandoned {
+		t.Fatalf("results = %+v, %v, want op1 abandoned", results, err)
+	}
+	if _, ok, _ := store.Get(ctx, "event-1"); ok {
+		t.Fatal("abandoned key not released")
+	}
+	if _, ok, _ := store.Get(ctx, "event-2"); !ok {
+		t.Fatal("key of a newer operation released")
+	}
+}
+
+func TestReleaseEWTByPartnerOnceConfirmsCommitted(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	store := junyousdk.NewMemoryIdempotencyStore()
+	client := newFlowClient(t, srv, srv.Config().WithIdempotencyStore(store))
+	receiver := srv.AddUser("13800138000")
+	signer := newTestSigner(t)
+	srv.FailNext(junyousdk.APIPathEWTConfirmReleaseByPartner, http.StatusBadRequest)
+
+	req := junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount: junyousdk.MustParseAmount("100"),
+		Ratio:  junyousdk.MustParseRatio("0.5"),
+	}
+	first, err := client.API().ReleaseEWTByPartnerOnce(context.Background(), "event-1", receiver, req, signer)
+	if !first.Committed() || err == nil {
+		t.Fatalf("first = %+v, %v, want committed with confirm error", first, err)
+	}
+
+	req.Amount = junyousdk.MustParseAmount("100.00")
+	second, err := client.API().ReleaseEWTByPartnerOnce(context.Background(), "event-1", receiver, req, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.Replayed || !second.Confirmed() || second.BizNo != first.BizNo {
+		t.Fatalf("second = %+v, want replayed confirm of %s", second, first.BizNo)
+	}
+	if got := srv.EWTBalance(receiver); got != "50" {
+		t.Fatalf("EWTBalance = %s, want 50", got)
+	}
+	record, _, _ := store.Get(context.Background(), "event-1")
+	if record.Status != junyousdk.IdempotencyCompleted {
+		t.Fatalf("record = %+v, want completed", record)
+	}
+}
@@ -35,3 +35,14 @@ func HMACSHA256(key []byte, data []byte) []byte {
 	hash.Write(data)
 	return hash.Sum(nil)
 }
+
+// FormatFixed 将 n 按字符集进制编码为定长字符串（高位补字符集首字符，超出长度时保留低位）
+func FormatFixed(n uint64, width int) string {
+	base := uint64(len(charset))
+	out := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		out[i] = charset[n%base]
+		n /= base
+	}
+	return string(out)
+}
@@ -0,0 +1,402 @@
+package junyousdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OperationKind 两阶段操作类型
+type OperationKind string
+
+const (
+	// OperationGOCReward GOC 奖励发放（RewardGOCFlow）
+	OperationGOCReward OperationKind = "goc_reward"
+	// OperationEWTReleaseByPartner 权证合伙人释放（ReleaseEWTByPartner）
+	OperationEWTReleaseByPartner OperationKind = "ewt_release_by_partner"
+)
+
+// OperationPhase 两阶段操作所处阶段
+type OperationPhase string
+
+const (
+	// PhaseStarted 已开始，尚未取得 biz_no
+	PhaseStarted OperationPhase = "started"
+	// PhasePreSubmitted 预提交成功，已取得 biz_no 与 message
+	PhasePreSubmitted OperationPhase = "pre_submitted"
+	// PhaseSigned 已签名，待提交
+	PhaseSigned OperationPhase = "signed"
+	// PhaseCommitted 已提交上链；GOC 奖励至此完成，权证释放还需确认
+	PhaseCommitted OperationPhase = "committed"
+	// PhaseConfirmed 权证释放已确认
+	PhaseConfirmed OperationPhase = "confirmed"
+	// PhaseAbandoned 已放弃（未提交）
+	PhaseAbandoned OperationPhase = "abandoned"
+	// PhaseFailed 已失败且确定未提交
+	PhaseFailed OperationPhase = "failed"
+)
+
+// JournalEntry 日志条目，记录一次阶段变化；字段为空表示本次不变
+type JournalEntry struct {
+	OperationId string                         `json:"operation_id"`
+	Kind        OperationKind                  `json:"kind"`
+	Phase       OperationPhase                 `json:"phase"`
+	Time        time.Time                      `json:"time"`
+	OpenId      string                         `json:"open_id,omitempty"`    // 收款方 / 接收方 OpenId
+	Amount      string                         `json:"amount,omitempty"`     // GOC 奖励金额
+	Request     *PreEWTReleaseByPartnerRequest `json:"request,omitempty"`    // 权证释放预提交请求
+	BizNo       string                         `json:"biz_no,omitempty"`     // 业务单号
+	Message     string                         `json:"message,omitempty"`    // 待签名 message，与预提交 data 逐字节一致
+	PublicKey   string                         `json:"public_key,omitempty"` // 签名公钥
+	DerHex      string                         `json:"der_hex,omitempty"`    // DER 签名十六进制
+	TxHash      string                         `json:"tx_hash,omitempty"`    // 链上交易哈希
+	Error       string                         `json:"error,omitempty"`      // 失败原因
+	// IdempotencyKey 由 RewardGOCOnce / ReleaseEWTByPartnerOnce 发起时的幂等键，供 Reconcile 更新幂等记录
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// Journal 两阶段操作日志：按顺序追加阶段变化，进程重启后由 Resume 读取未完成的操作。
+// 实现须保证 Append 返回前条目已持久化，且并发安全。
+type Journal interface {
+	// Append 追加条目
+	Append(ctx context.Context, entry JournalEntry) error
+	// Entries 按追加顺序返回全部条目
+	Entries(ctx context.Context) ([]JournalEntry, error)
+}
+
+// Operation 由日志条目合并得到的操作当前状态
+type Operation struct {
+	OperationId string
+	Kind        OperationKind
+	Phase       OperationPhase
+	OpenId      string
+	Amount      string
+	Request     *PreEWTReleaseByPartnerRequest
+	BizNo       string
+	Message     string
+	PublicKey   string
+	DerHex      string
+	TxHash      string
+	Error       string
+	// IdempotencyKey 幂等键，非幂等调用时为空
+	IdempotencyKey string
+	// StartedAt 首个条目时间
+	StartedAt time.Time
+	// UpdatedAt 最后一个条目时间
+	UpdatedAt time.Time
+}
+
+// Completed 操作是否已结束（无需 Resume 处理）
+func (o *Operation) Completed() bool {
+	switch o.Phase {
+	case PhaseConfirmed, PhaseAbandoned, PhaseFailed:
+		return true
+	case PhaseCommitted:
+		return o.Kind != OperationEWTReleaseByPartner
+	default:
+		return false
+	}
+}
+
+// apply 合并一个条目
+func (o *Operation) apply(e JournalEntry) {
+	if o.OperationId == "" {
+		o.OperationId = e.OperationId
+		o.StartedAt = e.Time
+	}
+	if e.Kind != "" {
+		o.Kind = e.Kind
+	}
+	if e.Phase != "" {
+		o.Phase = e.Phase
+	}
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&o.OpenId, e.OpenId)
+	set(&o.Amount, e.Amount)
+	set(&o.BizNo, e.BizNo)
+	set(&o.Message, e.Message)
+	set(&o.PublicKey, e.PublicKey)
+	set(&o.DerHex, e.DerHex)
+	set(&o.TxHash, e.TxHash)
+	set(&o.IdempotencyKey, e.IdempotencyKey)
+	o.Error = e.Error
+	if e.Request != nil {
+		o.Request = e.Request
+	}
+	o.UpdatedAt = e.Time
+}
+
+// JournalOperations 读取日志并按操作合并，按开始时间排序
+func JournalOperations(ctx context.Context, journal Journal) ([]Operation, error) {
+	entries, err := journal.Entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]*Operation{}
+	var ops []*Operation
+	for _, e := range entries {
+		op, ok := index[e.OperationId]
+		if !ok {
+			op = &Operation{}
+			index[e.OperationId] = op
+			ops = append(ops, op)
+		}
+		op.apply(e)
+	}
+	sort.SliceStable(ops, func(i, j int) bool { return ops[i].StartedAt.Before(ops[j].StartedAt) })
+
+	out := make([]Operation, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, *op)
+	}
+	return out, nil
+}
+
+// IncompleteOperations 返回日志中未完成的操作
+func IncompleteOperations(ctx context.Context, journal Journal) ([]Operation, error) {
+	ops, err := JournalOperations(ctx, journal)
+	if err != nil {
+		return nil, err
+	}
+	incomplete := ops[:0]
+	for _, op := range ops {
+		if !op.Completed() {
+			incomplete = append(incomplete, op)
+		}
+	}
+	return incomplete, nil
+}
+
+// newOperationId 生成操作 ID
+func newOperationId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate operation id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// operationJournal 流程内的日志写入器；未配置 Config.Journal 时为空操作
+type operationJournal struct {
+	journal Journal
+	clock   Clock
+	logger  *slog.Logger
+	id      string
+	kind    OperationKind
+}
+
+// newOperationJournal 为一次流程创建日志写入器；id 为空时生成新的操作 ID
+func (s *APIService) newOperationJournal(kind OperationKind, id string) (*operationJournal, error) {
+	config := s.client.config
+	j := &operationJournal{journal: config.Journal, clock: config.Clock, logger: config.Logger, kind: kind}
+	if j.journal == nil {
+		return j, nil
+	}
+	if id == "" {
+		var err error
+		if id, err = newOperationId(); err != nil {
+			return nil, err
+		}
+	}
+	j.id = id
+	return j, nil
+}
+
+// record 写入条目。提交前的阶段必须写入成功，否则中断流程，避免出现日志无记录的已提交操作
+func (j *operationJournal) record(ctx context.Context, entry JournalEntry) error {
+	if j.journal == nil {
+		return nil
+	}
+	entry.OperationId = j.id
+	entry.Kind = j.kind
+	entry.Time = j.clock.Now()
+	if err := j.journal.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// recordBestEffort 写入条目，失败时仅记录日志：
+// 用于提交后的阶段与失败状态，即使未写入，Resume 重新提交时也会因业务单号重复而识别为已提交
+func (j *operationJournal) recordBestEffort(ctx context.Context, entry JournalEntry) {
+	if err := j.record(ctx, entry); err != nil && j.logger != nil {
+		j.logger.LogAttrs(ctx, slog.LevelWarn, "junyousdk journal write failed",
+			slog.String("operation_id", j.id),
+			slog.String("phase", string(entry.Phase)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// fail 记录提交前的失败
+func (j *operationJournal) fail(ctx context.Context, bizNo string, err error) {
+	j.recordBestEffort(ctx, JournalEntry{Phase: PhaseFailed, BizNo: bizNo, Error: err.Error()})
+}
+
+// commitFailed 按提交错误记录阶段，见 commitFailurePhase
+func (j *operationJournal) commitFailed(ctx context.Context, err error) {
+	j.recordBestEffort(ctx, JournalEntry{Phase: commitFailurePhase(err), Error: err.Error()})
+}
+
+// commitFailurePhase 判断提交失败后的阶段：
+// 业务单号重复说明此前已提交成功；本地签名校验失败说明请求未发出；业务错误或 4xx（408、429 除外）说明服务端明确拒绝；
+// 其余（网络错误、5xx 等）无法确定是否已提交，保持 PhaseSigned 由 Resume 重新提交
+func commitFailurePhase(err error) OperationPhase {
+	if errors.Is(err, ErrDuplicateBizNo) {
+		return PhaseCommitted
+	}
+	if errors.Is(err, ErrChainSignatureInvalid) {
+		return PhaseFailed
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return PhaseSigned
+	}
+	switch {
+	case apiErr.StatusCode == http.StatusOK:
+		return PhaseFailed
+	case apiErr.StatusCode == http.StatusRequestTimeout || apiErr.StatusCode == http.StatusTooManyRequests:
+		return PhaseSigned
+	case apiErr.StatusCode >= 400 && apiErr.StatusCode < 500:
+		return PhaseFailed
+	default:
+		return PhaseSigned
+	}
+}
+
+// MemoryJournal 进程内 Journal，进程退出后丢失，适用于测试
+type MemoryJournal struct {
+	mu      sync.Mutex
+	entries []JournalEntry
+}
+
+// NewMemoryJournal 创建进程内 Journal
+func NewMemoryJournal() *MemoryJournal {
+	return &MemoryJournal{}
+}
+
+// Append 实现 Journal
+func (j *MemoryJournal) Append(_ context.Context, entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+// Entries 实现 Journal
+func (j *MemoryJournal) Entries(_ context.Context) ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]JournalEntry(nil), j.entries...), nil
+}
+
+// FileJournal 基于文件的 Journal：每个条目一行 JSON（JSONL），只追加，每次 Append 后 fsync
+type FileJournal struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewFileJournal 打开（不存在时创建）path 处的日志文件。
+// 上次进程在写入中途退出留下的不完整末行会被截掉，避免与之后追加的条目拼接
+func NewFileJournal(path string) (*FileJournal, error) {
+	if err := truncatePartialLine(path); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	return &FileJournal{path: path, file: file}, nil
+}
+
+// truncatePartialLine 将文件截断到最后一个换行符之后；文件不存在时忽略
+func truncatePartialLine(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read journal: %w", err)
+	}
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return nil
+	}
+	size := bytes.LastIndexByte(data, '\n') + 1
+	if err := os.Truncate(path, int64(size)); err != nil {
+		return fmt.Errorf("failed to repair journal: %w", err)
+	}
+	return nil
+}
+
+// Append 实现 Journal
+func (j *FileJournal) Append(_ context.Context, entry JournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	return nil
+}
+
+// Entries 实现 Journal。进程在写入中途退出时最后一行可能不完整，该行会被忽略。
+// 每次调用都读取并解析整个文件，耗时与文件大小成正比；文件只增不减，
+// 应在 IncompleteOperations 为空时（如 Resume 处理完毕后）关闭并删除或轮转文件，不要让其无限增长
+func (j *FileJournal) Entries(_ context.Context) ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var entries []JournalEntry
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			// 最后一行没有换行符，说明写入未完成
+			if i == len(lines)-1 {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse journal line %d: %w", i+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Close 关闭日志文件
+func (j *FileJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
@@ -0,0 +1,100 @@
+package junyousdk_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+func TestFlowJournalPhases(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	journal := junyousdk.NewMemoryJournal()
+	client := newFlowClient(t, srv, srv.Config().WithJournal(journal))
+	openId := srv.AddUser("13800138000")
+
+	report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("1"), newTestSigner(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := journal.Entries(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []junyousdk.OperationPhase{junyousdk.PhaseStarted, junyousdk.PhasePreSubmitted, junyousdk.PhaseSigned, junyousdk.PhaseCommitted}
+	if len(entries) != len(want) {
+		t.Fatalf("entries = %+v, want phases %v", entries, want)
+	}
+	for i, e := range entries {
+		if e.Phase != want[i] || e.OperationId != report.OperationId || e.Kind != junyousdk.OperationGOCReward {
+			t.Fatalf("entry %d = %+v, want phase %s of %s", i, e, want[i], report.OperationId)
+		}
+	}
+
+	ops, err := junyousdk.JournalOperations(context.Background(), journal)
+	if err != nil || len(ops) != 1 {
+		t.Fatalf("operations = %+v, %v", ops, err)
+	}
+	op := ops[0]
+	if op.BizNo != report.BizNo || op.Message != report.Message || op.DerHex != report.DerHex || op.TxHash == "" || !op.Completed() {
+		t.Fatalf("operation = %+v, want merged completed operation", op)
+	}
+}
+
+func TestFileJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	journal, err := junyousdk.NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []junyousdk.JournalEntry{
+		{OperationId: "op1", Kind: junyousdk.OperationGOCReward, Phase: junyousdk.PhaseStarted, Time: now, OpenId: "u", Amount: "1"},
+		{OperationId: "op1", Kind: junyousdk.OperationGOCReward, Phase: junyousdk.PhasePreSubmitted, Time: now, BizNo: "GOC1", Message: `{"a":1}`},
+	}
+	for _, e := range entries {
+		if err := journal.Append(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 模拟写入中途退出留下的不完整末行
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"operation_id":"op1","phase":"sig`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	journal, err = junyousdk.NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+	if err := journal.Append(ctx, junyousdk.JournalEntry{OperationId: "op1", Kind: junyousdk.OperationGOCReward, Phase: junyousdk.PhaseSigned, Time: now, PublicKey: "04ab", DerHex: "30"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := journal.Entries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[2].Phase != junyousdk.PhaseSigned || got[1].Message != `{"a":1}` || !got[0].Time.Equal(now) {
+		t.Fatalf("entries = %+v, want started, pre_submitted, signed", got)
+	}
+	ops, err := junyousdk.IncompleteOperations(ctx, journal)
+	if err != nil || len(ops) != 1 || ops[0].Phase != junyousdk.PhaseSigned || ops[0].BizNo != "GOC1" {
+		t.Fatalf("incomplete = %+v, %v, want op1 signed", ops, err)
+	}
+}
@@ -0,0 +1,77 @@
+package junyoutest
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// decimalPattern 十进制数字符串格式
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// decimal 账本使用的精确十进制数（仅做加减乘，结果保持有限小数）
+type decimal struct {
+	r big.Rat
+}
+
+// newDecimal 返回 0
+func newDecimal() *decimal {
+	return &decimal{}
+}
+
+// parseDecimal 解析十进制数字符串
+func parseDecimal(s string) (*decimal, error) {
+	if !decimalPattern.MatchString(s) {
+		return nil, fmt.Errorf("junyoutest: invalid decimal %q", s)
+	}
+	d := &decimal{}
+	if _, ok := d.r.SetString(s); !ok {
+		return nil, fmt.Errorf("junyoutest: invalid decimal %q", s)
+	}
+	return d, nil
+}
+
+// add 返回 d + o
+func (d *decimal) add(o *decimal) *decimal {
+	out := &decimal{}
+	out.r.Add(&d.r, &o.r)
+	return out
+}
+
+// sub 返回 d - o
+func (d *decimal) sub(o *decimal) *decimal {
+	out := &decimal{}
+	out.r.Sub(&d.r, &o.r)
+	return out
+}
+
+// mul 返回 d * o
+func (d *decimal) mul(o *decimal) *decimal {
+	out := &decimal{}
+	out.r.Mul(&d.r, &o.r)
+	return out
+}
+
+// cmp 比较 d 与 o
+func (d *decimal) cmp(o *decimal) int {
+	return d.r.Cmp(&o.r)
+}
+
+// sign 返回符号
+func (d *decimal) sign() int {
+	return d.r.Sign()
+}
+
+// String 格式化为去掉末尾 0 的十进制字符串
+func (d *decimal) String() string {
+	s := d.r.FloatString(18)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
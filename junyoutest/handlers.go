@@ -0,0 +1,435 @@
+package junyoutest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+)
+
+// gocRewardMessage GOC 预提交返回的待签名消息（字段顺序即序列化顺序）
+type gocRewardMessage struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Amount  string `json:"amount"`
+	BizNo   string `json:"biz_no"`
+	BizType string `json:"biz_type"`
+	BizDesc string `json:"biz_desc"`
+}
+
+// ewtReleaseMessage 权证合伙人释放预提交返回的待签名消息
+type ewtReleaseMessage struct {
+	BizNo        string `json:"biz_no"`
+	BizType      string `json:"biz_type"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	OpenId       string `json:"open_id"`
+	Amount       string `json:"amount"`
+	Ratio        string `json:"ratio"`
+	Level1OpenId string `json:"level1_open_id"`
+	Level1Ratio  string `json:"level1_ratio"`
+	Level2OpenId string `json:"level2_open_id"`
+	Level2Ratio  string `json:"level2_ratio"`
+}
+
+// commitReceipt 提交接口返回
+type commitReceipt struct {
+	BizNo  string `json:"biz_no"`
+	TxHash string `json:"tx_hash"`
+	Status string `json:"status"`
+}
+
+// balanceItem 余额查询列表项
+type balanceItem struct {
+	OpenId  string `json:"open_id"`
+	Balance string `json:"balance"`
+}
+
+// pageData 分页查询返回
+type pageData[T any] struct {
+	List     []T `json:"list"`
+	Total    int `json:"total"`
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+// 业务错误消息
+const (
+	msgMissingIdentity = "校验失败：缺少用户身份"
+	msgTokenReused     = "token无效：每次预提交须使用新的 Token"
+	msgBadParams       = "参数错误"
+	msgUserNotFound    = "用户不存在"
+	msgBizNoNotFound   = "业务单号不存在"
+	msgDuplicateBizNo  = "业务单号重复提交"
+	msgMessageMismatch = "message 与预提交不一致"
+	msgSignature       = "签名校验失败"
+	msgInsufficient    = "余额不足"
+	msgNotCommitted    = "业务单号尚未提交"
+)
+
+// decodeBody 解析请求体，失败时写入参数错误
+func (s *Server) decodeBody(w http.ResponseWriter, body []byte, v any) bool {
+	if err := json.Unmarshal(body, v); err != nil {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgBadParams)
+		return false
+	}
+	return true
+}
+
+// handleRegister POST /api/open/v1/register，data 为 open_id
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req junyousdk.RegisterInfo
+	if !s.decodeBody(w, body, &req) {
+		return
+	}
+	if strings.TrimSpace(req.PhoneNumber) == "" {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgBadParams)
+		return
+	}
+	s.writeResult(w, s.addUserLocked(req.PhoneNumber))
+}
+
+// handleIssueToken POST /api/open/v1/auth/{login,set_pwd,cmt}，data 为新签发的令牌
+func (s *Server) handleIssueToken(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req junyousdk.OpenIdToken
+	if !s.decodeBody(w, body, &req) {
+		return
+	}
+	if _, ok := s.users[req.OpenId]; !ok {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgUserNotFound)
+		return
+	}
+	t := randomHex(16)
+	s.tokens[t] = &token{openId: req.OpenId}
+	s.writeResult(w, t)
+}
+
+// handleEnterpriseJKSURL POST /api/open/v1/enterprise/jks_url
+func (s *Server) handleEnterpriseJKSURL(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req junyousdk.EnterpriseJKSURLRequest
+	if !s.decodeBody(w, body, &req) {
+		return
+	}
+	if strings.TrimSpace(req.JKSUrl) == "" {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgBadParams)
+		return
+	}
+	s.jksURL = req.JKSUrl
+	s.writeResult(w, map[string]any{"jks_url": s.jksURL})
+}
+
+// handlePreRewardGOC POST /api/open/v1/goc/pre_reward，须携带未用于预提交的 X-Open-Auth
+func (s *Server) handlePreRewardGOC(w http.ResponseWriter, r *http.Request, body []byte) {
+	openId, t := s.openIdFromAuth(r)
+	if t == nil {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeMissingUserIdentity, msgMissingIdentity)
+		return
+	}
+	if t.used {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeOpenAuthInvalid, msgTokenReused)
+		return
+	}
+
+	var req struct {
+		Amount string `json:"amount"`
+	}
+	if !s.decodeBody(w, body, &req) {
+		return
+	}
+	amount, err := parseDecimal(req.Amount)
+	if err != nil || amount.sign() <= 0 {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgBadParams)
+		return
+	}
+	if s.gocBalance != nil && s.gocBalance.cmp(amount) < 0 {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeInsufficientBalance, msgInsufficient)
+		return
+	}
+	t.used = true
+
+	bizNo := s.nextBizNo("GOC")
+	message, _ := json.Marshal(gocRewardMessage{
+		From:    s.EnterpriseAddress,
+		To:      s.users[openId].address,
+		Amount:  req.Amount,
+		BizNo:   bizNo,
+		BizType: BizTypeGOCReward,
+		BizDesc: "企业奖励",
+	})
+	s.pending[bizNo] = &order{
+		bizNo:   bizNo,
+		bizType: BizTypeGOCReward,
+		openId:  openId,
+		message: message,
+		amount:  amount,
+	}
+	s.writeResult(w, json.RawMessage(message))
+}
+
+// handleRewardGOC POST /api/open/v1/goc/reward
+func (s *Server) handleRewardGOC(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req junyousdk.CommitGOCRewardRequest
+	if !s.decodeBody(w, body, &req) {
+		return
+	}
+	o, ok := s.checkCommitLocked(w, BizTypeGOCReward, req.BizNo, req.Message, req.PublicKey, req.DerHex)
+	if !ok {
+		return
+	}
+	if s.gocBalance != nil {
+		if s.gocBalance.cmp(o.amount) < 0 {
+			s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeInsufficientBalance, msgInsufficient)
+			return
+		}
+		s.gocBalance = s.gocBalance.sub(o.amount)
+	}
+	acc := s.users[o.openId]
+	acc.goc = acc.goc.add(o.amount)
+	o.committed = true
+
+	s.writeResult(w, commitReceipt{BizNo: o.bizNo, TxHash: "0x" + randomHex(32), Status: "success"})
+}
+
+// handlePreEWTRelease POST /api/open/v1/ewt/pre_ewt_rbp_open，须携带 X-Open-Auth（接收方）
+func (s *Server) handlePreEWTRelease(w http.ResponseWriter, r *http.Request, body []byte) {
+	openId, t := s.openIdFromAuth(r)
+	if t == nil {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeMissingUserIdentity, msgMissingIdentity)
+		return
+	}
+
+	var req junyousdk.PreEWTReleaseByPartnerRequest
+	if !s.decodeBody(w, body, &req) {
+		return
+	}
+	amount, err := parseDecimal(req.Amount.String())
+	if err != nil || amount.sign() <= 0 {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgBadParams)
+		return
+	}
+	for _, partner := range []string{req.Level1OpenId, req.Level2OpenId} {
+		if _, ok := s.users[partner]; partner != "" && !ok {
+			s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgUserNotFound)
+			return
+		}
+	}
+
+	bizNo := s.nextBizNo("EWT")
+	message, _ := json.Marshal(ewtReleaseMessage{
+		BizNo:        bizNo,
+		BizType:      BizTypeEWTReleaseByPartner,
+		From:         s.EnterpriseAddress,
+		To:           s.users[openId].address,
+		OpenId:       openId,
+		Amount:       req.Amount.String(),
+		Ratio:        req.Ratio.String(),
+		Level1OpenId: req.Level1OpenId,
+		Level1Ratio:  req.Level1Ratio.String(),
+		Level2OpenId: req.Level2OpenId,
+		Level2Ratio:  req.Level2Ratio.String(),
+	})
+	release := req
+	s.pending[bizNo] = &order{
+		bizNo:   bizNo,
+		bizType: BizTypeEWTReleaseByPartner,
+		openId:  openId,
+		message: message,
+		amount:  amount,
+		release: &release,
+	}
+	s.writeResult(w, json.RawMessage(message))
+}
+
+// handleCommitEWTRelease POST /api/open/v1/ewt/commit_ewt_rbp
+func (s *Server) handleCommitEWTRelease(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req junyousdk.CommitEWTReleaseByPartnerRequest
+	if !s.decodeBody(w, body, &req) {
+		return
+	}
+	o, ok := s.checkCommitLocked(w, BizTypeEWTReleaseByPartner, req.BizNo, req.Message, req.PublicKey, req.DerHex)
+	if !ok {
+		return
+	}
+	o.committed = true
+	s.writeResult(w, commitReceipt{BizNo: o.bizNo, TxHash: "0x" + randomHex(32), Status: "committed"})
+}
+
+// handleConfirmEWTRelease POST /api/open/v1/ewt/confirm_ewt_rbp：入账接收方与各级合伙人。
+// 分配规则：一级 = amount × level1_ratio，二级 = amount × level2_ratio，接收方 = amount × ratio − 一级 − 二级。
+func (s *Server) handleConfirmEWTRelease(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req junyousdk.EWTBizNoInfo
+	if !s.decodeBody(w, body, &req) {
+		return
+	}
+	o, ok := s.pending[req.EWTBizNo]
+	if !ok || o.bizType != BizTypeEWTReleaseByPartner {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgBizNoNotFound)
+		return
+	}
+	if !o.committed {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgNotCommitted)
+		return
+	}
+	if o.confirmed {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeDuplicateBizNo, msgDuplicateBizNo)
+		return
+	}
+
+	share := func(ratio junyousdk.Ratio) *decimal {
+		if !ratio.IsSet() {
+			return newDecimal()
+		}
+		d, _ := parseDecimal(ratio.String())
+		return o.amount.mul(d)
+	}
+	released := share(o.release.Ratio)
+	level1 := share(o.release.Level1Ratio)
+	level2 := share(o.release.Level2Ratio)
+	receiver := released.sub(level1).sub(level2)
+
+	now := s.now()
+	credit := func(openId string, amount *decimal) {
+		acc, ok := s.users[openId]
+		if !ok || amount.sign() <= 0 {
+			return
+		}
+		acc.ewt = acc.ewt.add(amount)
+		s.txs = append(s.txs, Transaction{
+			BizNo:           o.bizNo,
+			OpenId:          openId,
+			TransactionType: "in",
+			BizType:         BizTypeEWTReleaseByPartner,
+			Amount:          amount.String(),
+			CreatedAt:       now.Format(time.RFC3339),
+			createdAt:       now,
+		})
+	}
+	credit(o.openId, receiver)
+	credit(o.release.Level1OpenId, level1)
+	credit(o.release.Level2OpenId, level2)
+	o.confirmed = true
+
+	s.writeResult(w, "success")
+}
+
+// checkCommitLocked 校验提交请求：单号存在且未提交、message 与预提交一致、签名有效
+func (s *Server) checkCommitLocked(w http.ResponseWriter, bizType, bizNo, message, publicKey, derHex string) (*order, bool) {
+	o, ok := s.pending[bizNo]
+	if !ok || o.bizType != bizType {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgBizNoNotFound)
+		return nil, false
+	}
+	if o.committed {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeDuplicateBizNo, msgDuplicateBizNo)
+		return nil, false
+	}
+	if message != string(o.message) {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, "", msgMessageMismatch)
+		return nil, false
+	}
+	if publicKey == "" || derHex == "" {
+		s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeSignatureInvalid, msgSignature)
+		return nil, false
+	}
+	if s.verifySig != nil {
+		if err := s.verifySig(message, publicKey, derHex); err != nil {
+			s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeSignatureInvalid, msgSignature+": "+err.Error())
+			return nil, false
+		}
+	}
+	return o, true
+}
+
+// handleEWTBalance GET /api/open/v1/ewt/balance：带 X-Open-Auth 时返回该用户，否则返回企业下全部用户
+func (s *Server) handleEWTBalance(w http.ResponseWriter, r *http.Request, body []byte) {
+	page, pageSize := pageParams(r)
+
+	var items []balanceItem
+	if r.Header.Get(junyousdk.HeaderOpenAuth) != "" {
+		openId, t := s.openIdFromAuth(r)
+		if t == nil {
+			s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeMissingUserIdentity, msgMissingIdentity)
+			return
+		}
+		items = append(items, balanceItem{OpenId: openId, Balance: s.users[openId].ewt.String()})
+	} else {
+		for openId, acc := range s.users {
+			items = append(items, balanceItem{OpenId: openId, Balance: acc.ewt.String()})
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].OpenId < items[j].OpenId })
+	}
+
+	s.writeResult(w, paginate(items, page, pageSize))
+}
+
+// handleEWTTransactionDetails GET /api/open/v1/ewt/transaction_details，按创建时间倒序
+func (s *Server) handleEWTTransactionDetails(w http.ResponseWriter, r *http.Request, body []byte) {
+	page, pageSize := pageParams(r)
+	query := r.URL.Query()
+
+	var openId string
+	if r.Header.Get(junyousdk.HeaderOpenAuth) != "" {
+		var t *token
+		openId, t = s.openIdFromAuth(r)
+		if t == nil {
+			s.writeError(w, http.StatusOK, http.StatusBadRequest, junyousdk.ErrCodeMissingUserIdentity, msgMissingIdentity)
+			return
+		}
+	}
+	year, _ := strconv.Atoi(query.Get("year"))
+	month, _ := strconv.Atoi(query.Get("month"))
+
+	var items []Transaction
+	for _, tx := range s.txs {
+		if openId != "" && tx.OpenId != openId {
+			continue
+		}
+		if v := query.Get("transaction_type"); v != "" && tx.TransactionType != v {
+			continue
+		}
+		if v := query.Get("biz_type"); v != "" && tx.BizType != v {
+			continue
+		}
+		if year > 0 && tx.createdAt.Year() != year {
+			continue
+		}
+		if month > 0 && int(tx.createdAt.Month()) != month {
+			continue
+		}
+		items = append(items, tx)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].createdAt.After(items[j].createdAt) })
+
+	s.writeResult(w, paginate(items, page, pageSize))
+}
+
+// pageParams 解析分页参数
+func pageParams(r *http.Request) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
+
+// paginate 截取分页
+func paginate[T any](items []T, page, pageSize int) pageData[T] {
+	out := pageData[T]{List: []T{}, Total: len(items), Page: page, PageSize: pageSize}
+	start := (page - 1) * pageSize
+	if start < len(items) {
+		end := start + pageSize
+		if end > len(items) {
+			end = len(items)
+		}
+		out.List = items[start:end]
+	}
+	return out
+}
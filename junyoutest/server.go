@@ -0,0 +1,496 @@
+// Package junyoutest 提供基于 httptest 的 Junyou Open API 内存模拟服务，用于在测试中替代 open-api.junyouchain.com。
+//
+// 模拟服务实现 junyousdk 中定义的全部接口路径，按与真实服务端相同的规则校验 HMAC 签名 Header，
+// 签发 Open Token，并在内存中维护 EWT / GOC 账本：
+//
+//	srv := junyoutest.NewServer()
+//	defer srv.Close()
+//
+//	client, _ := junyousdk.NewClient(srv.Config())
+//	openId := srv.AddUser("13800138000")
+//
+// 模拟账户的链上地址由确定性私钥推导：私钥为 SHA-256(seed)，企业账户 seed 为 "enterprise:" + DefaultAccessId，
+// 用户为 "user:" + open_id；地址按 keys.PublicKey.Address（以太坊方式的 Keccak-256）计算。
+// 真实链上地址的推导规则未见文档，模拟地址与之一致只是假设。
+// EnterpriseSigner 返回企业账户私钥的签名器。
+package junyoutest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/keys"
+)
+
+// 模拟服务使用的业务类型
+const (
+	BizTypeGOCReward           = "GOC_REWARD"
+	BizTypeEWTReleaseByPartner = "EWT1005"
+)
+
+// 默认凭证
+const (
+	DefaultAccessId  = "junyoutest-access-id"
+	DefaultAccessKey = "anVueW91dGVzdC1hY2Nlc3Mta2V5LTAwMDAwMDAwMDA=" // base64("junyoutest-access-key-0000000000")
+)
+
+// Server 内存模拟的 Junyou Open API 服务
+type Server struct {
+	// URL 服务地址，可直接作为 Config.Address
+	URL string
+	// AccessId 服务端接受的访问 ID
+	AccessId string
+	// AccessKey 服务端接受的访问密钥（Base64 编码）
+	AccessKey string
+	// EnterpriseAddress 企业出账链上地址，作为 GOC 预提交消息的 from；默认为 EnterpriseSigner 的地址
+	EnterpriseAddress string
+
+	httpServer    *httptest.Server
+	verifier      *junyousdk.Verifier
+	enterpriseKey *keys.PrivateKey
+
+	mu         sync.Mutex
+	wrapped    bool
+	users      map[string]*account // open_id -> 账户
+	phones     map[string]string   // 手机号 -> open_id
+	tokens     map[string]*token   // Open Token -> 令牌信息
+	pending    map[string]*order   // biz_no -> 待提交单据
+	txs        []Transaction
+	gocBalance *decimal // 企业 GOC 余额，nil 表示不限
+	jksURL     string
+	bizSeq     int
+	failures   map[string][]int // API 路径 -> 待注入的 HTTP 状态码
+	requests   []RecordedRequest
+	verifySig  func(message, publicKey, derHex string) error
+	clock      junyousdk.Clock
+}
+
+// RecordedRequest 模拟服务收到的请求记录
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// Transaction 账本流水
+type Transaction struct {
+	BizNo           string `json:"biz_no"`
+	OpenId          string `json:"open_id"`
+	TransactionType string `json:"transaction_type"` // in / out
+	BizType         string `json:"biz_type"`
+	Amount          string `json:"amount"`
+	CreatedAt       string `json:"created_at"` // RFC3339
+
+	createdAt time.Time
+}
+
+// account 用户账户
+type account struct {
+	openId  string
+	phone   string
+	address string
+	ewt     *decimal
+	goc     *decimal
+}
+
+// token Open Token
+type token struct {
+	openId string
+	used   bool // 已用于 GOC 预提交，不能再次预提交
+}
+
+// order 预提交单据
+type order struct {
+	bizNo     string
+	bizType   string
+	openId    string
+	message   []byte
+	amount    *decimal
+	release   *junyousdk.PreEWTReleaseByPartnerRequest
+	committed bool
+	confirmed bool
+}
+
+// NewServer 启动模拟服务，使用默认凭证与带 result 包装的响应格式
+func NewServer() *Server {
+	enterpriseKey := chainKey("enterprise:" + DefaultAccessId)
+	s := &Server{
+		AccessId:          DefaultAccessId,
+		AccessKey:         DefaultAccessKey,
+		EnterpriseAddress: enterpriseKey.PublicKey().Address(),
+		enterpriseKey:     enterpriseKey,
+		wrapped:           true,
+		users:             map[string]*account{},
+		phones:            map[string]string{},
+		tokens:            map[string]*token{},
+		pending:           map[string]*order{},
+		failures:          map[string][]int{},
+		clock:             junyousdk.SystemClock,
+	}
+	s.verifier = junyousdk.NewVerifier(junyousdk.KeyStoreFunc(s.accessKey)).
+		WithNonceStore(junyousdk.NewMemoryNonceStoreWithClock(junyousdk.ClockFunc(s.now))).
+		WithClock(junyousdk.ClockFunc(s.now))
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.httpServer.URL
+	return s
+}
+
+// Close 关闭模拟服务
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// EnterpriseSigner 返回模拟企业账户私钥的签名器，其 Address() 等于默认的 EnterpriseAddress；
+// 可用于测试签名密钥与预提交消息 from 的核对。修改 EnterpriseAddress 后二者不再一致
+func (s *Server) EnterpriseSigner() *junyousdk.LocalSigner {
+	return junyousdk.NewLocalSignerFromKey(s.enterpriseKey)
+}
+
+// Config 返回指向模拟服务的 SDK 配置，已设置企业地址、业务类型与用户地址查询并开启 VerifyPreSubmit，流程方法会校验预提交消息
+func (s *Server) Config() *junyousdk.Config {
+	return junyousdk.DefaultConfig().
+		WithAccessId(s.AccessId).
+		WithAccessKey(s.AccessKey).
+		WithAddress(s.URL).
+		WithEnterpriseAddress(s.EnterpriseAddress).
+		WithBizTypes(BizTypeGOCReward, BizTypeEWTReleaseByPartner).
+		WithAddressResolver(func(_ context.Context, openId string) (string, error) {
+			if address := s.UserAddress(openId); address != "" {
+				return address, nil
+			}
+			return "", fmt.Errorf("junyoutest: unknown open_id %s", openId)
+		}).
+		WithVerifyPreSubmit(true)
+}
+
+// SetWrapped 设置响应格式：true 为 {"result":{...}} 包装格式（默认），false 为不带包装的格式
+func (s *Server) SetWrapped(wrapped bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wrapped = wrapped
+}
+
+// SetClock 设置服务端时钟，影响签名过期判断、业务单号日期与流水时间
+func (s *Server) SetClock(clock junyousdk.Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = clock
+}
+
+// now 返回服务端当前时间，调用时须持有 s.mu（签名校验与接口处理均在 serveHTTP 持锁期间执行）
+func (s *Server) now() time.Time {
+	return s.clock.Now()
+}
+
+// SetEnterpriseGOCBalance 设置企业 GOC 余额；不设置时余额不限
+func (s *Server) SetEnterpriseGOCBalance(amount string) error {
+	d, err := parseDecimal(amount)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gocBalance = d
+	return nil
+}
+
+// SetSignatureVerifier 设置提交接口对 message 签名的校验函数（如 junyousdk.VerifyChainSignature）；不设置时只检查 public_key、der_hex 非空
+func (s *Server) SetSignatureVerifier(verify func(message, publicKey, derHex string) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verifySig = verify
+}
+
+// FailNext 令之后对 apiPath 的请求依次返回给定 HTTP 状态码（每个状态码消耗一次），用于测试重试
+func (s *Server) FailNext(apiPath string, statusCodes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[apiPath] = append(s.failures[apiPath], statusCodes...)
+}
+
+// RevokeTokens 使 openId 已签发的全部 Open Token 失效，之后携带这些 Token 的请求返回缺少用户身份；用于测试 Token 过期
+func (s *Server) RevokeTokens(openId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for t, info := range s.tokens {
+		if info.openId == openId {
+			delete(s.tokens, t)
+		}
+	}
+}
+
+// AddUser 注册用户并返回 open_id；手机号已注册时返回已有 open_id
+func (s *Server) AddUser(phoneNumber string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addUserLocked(phoneNumber)
+}
+
+// UserAddress 返回用户链上地址（GOC 预提交消息的 to）
+func (s *Server) UserAddress(openId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.users[openId]; ok {
+		return acc.address
+	}
+	return ""
+}
+
+// SetEWTBalance 设置用户权证余额
+func (s *Server) SetEWTBalance(openId, amount string) error {
+	d, err := parseDecimal(amount)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	acc, ok := s.users[openId]
+	if !ok {
+		return fmt.Errorf("junyoutest: unknown open_id %s", openId)
+	}
+	acc.ewt = d
+	return nil
+}
+
+// EWTBalance 返回用户权证余额
+func (s *Server) EWTBalance(openId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.users[openId]; ok {
+		return acc.ewt.String()
+	}
+	return "0"
+}
+
+// GOCBalance 返回用户 GOC 余额
+func (s *Server) GOCBalance(openId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.users[openId]; ok {
+		return acc.goc.String()
+	}
+	return "0"
+}
+
+// AddTransaction 追加一条账本流水（CreatedAt 为空时取当前时间），用于构造查询数据
+func (s *Server) AddTransaction(tx Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	createdAt := s.now()
+	if tx.CreatedAt != "" {
+		t, err := time.Parse(time.RFC3339, tx.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("junyoutest: invalid created_at: %w", err)
+		}
+		createdAt = t
+	}
+	tx.createdAt = createdAt
+	tx.CreatedAt = createdAt.Format(time.RFC3339)
+	s.txs = append(s.txs, tx)
+	return nil
+}
+
+// Transactions 返回全部账本流水
+func (s *Server) Transactions() []Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Transaction(nil), s.txs...)
+}
+
+// Requests 返回已收到的请求记录（含签名校验失败的请求）
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// PreSubmitMessage 返回 biz_no 对应预提交消息的原始字节
+func (s *Server) PreSubmitMessage(bizNo string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if o, ok := s.pending[bizNo]; ok {
+		return append([]byte(nil), o.message...)
+	}
+	return nil
+}
+
+// Committed 返回 biz_no 是否已提交
+func (s *Server) Committed(bizNo string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.pending[bizNo]
+	return ok && o.committed
+}
+
+// addUserLocked 注册用户，调用方须持有 s.mu
+func (s *Server) addUserLocked(phoneNumber string) string {
+	if openId, ok := s.phones[phoneNumber]; ok {
+		return openId
+	}
+	sum := sha256.Sum256([]byte("open_id:" + phoneNumber))
+	openId := hex.EncodeToString(sum[:])
+	s.users[openId] = &account{
+		openId:  openId,
+		phone:   phoneNumber,
+		address: chainAddress("user:" + openId),
+		ewt:     newDecimal(),
+		goc:     newDecimal(),
+	}
+	s.phones[phoneNumber] = openId
+	return openId
+}
+
+// serveHTTP 处理请求：注入故障 → 校验签名 → 分发到接口
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.RequestURI(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if codes := s.failures[r.URL.Path]; len(codes) > 0 {
+		s.failures[r.URL.Path] = codes[1:]
+		s.writeError(w, codes[0], codes[0], "", http.StatusText(codes[0]))
+		return
+	}
+
+	if err := s.verifySignatureLocked(r); err != nil {
+		s.writeError(w, http.StatusUnauthorized, http.StatusUnauthorized, junyousdk.ErrCodeSignatureInvalid, err.Error())
+		return
+	}
+
+	handler, ok := s.routes()[r.Method+" "+r.URL.Path]
+	if !ok {
+		s.writeError(w, http.StatusNotFound, http.StatusNotFound, "", "not found")
+		return
+	}
+	handler(w, r, body)
+}
+
+// routeHandler 接口处理函数，调用时已持有 s.mu
+type routeHandler func(w http.ResponseWriter, r *http.Request, body []byte)
+
+// routes 接口路由表
+func (s *Server) routes() map[string]routeHandler {
+	return map[string]routeHandler{
+		http.MethodPost + " " + junyousdk.APIPathRegister:                   s.handleRegister,
+		http.MethodPost + " " + junyousdk.APIPathAuthLogin:                  s.handleIssueToken,
+		http.MethodPost + " " + junyousdk.APIPathAuthSetPWD:                 s.handleIssueToken,
+		http.MethodPost + " " + junyousdk.APIPathAuthCMT:                    s.handleIssueToken,
+		http.MethodPost + " " + junyousdk.APIPathEnterpriseJKSURL:           s.handleEnterpriseJKSURL,
+		http.MethodPost + " " + junyousdk.APIPathEWTPreOpenReleaseByPartner: s.handlePreEWTRelease,
+		http.MethodPost + " " + junyousdk.APIPathEWTCommitReleaseByPartner:  s.handleCommitEWTRelease,
+		http.MethodPost + " " + junyousdk.APIPathEWTConfirmReleaseByPartner: s.handleConfirmEWTRelease,
+		http.MethodGet + " " + junyousdk.APIPathEWTBalance:                  s.handleEWTBalance,
+		http.MethodGet + " " + junyousdk.APIPathEWTTransactionDetails:       s.handleEWTTransactionDetails,
+		http.MethodPost + " " + junyousdk.APIPathGOCPreReward:               s.handlePreRewardGOC,
+		http.MethodPost + " " + junyousdk.APIPathGOCReward:                  s.handleRewardGOC,
+	}
+}
+
+// verifySignatureLocked 使用 junyousdk.Verifier 按服务端规则校验签名 Header（含 nonce 防重放）
+func (s *Server) verifySignatureLocked(r *http.Request) error {
+	_, err := s.verifier.VerifyRequest(r)
+	return err
+}
+
+// accessKey 返回 AccessId 对应的 AccessKey；读取导出字段，测试中修改 AccessId/AccessKey 后立即生效
+func (s *Server) accessKey(_ context.Context, accessId string) (string, error) {
+	if accessId != s.AccessId {
+		return "", junyousdk.ErrUnknownAccessId
+	}
+	return s.AccessKey, nil
+}
+
+// openIdFromAuth 解析 X-Open-Auth 对应的 open_id
+func (s *Server) openIdFromAuth(r *http.Request) (string, *token) {
+	t, ok := s.tokens[strings.TrimSpace(r.Header.Get(junyousdk.HeaderOpenAuth))]
+	if !ok {
+		return "", nil
+	}
+	return t.openId, t
+}
+
+// nextBizNo 生成业务单号
+func (s *Server) nextBizNo(prefix string) string {
+	s.bizSeq++
+	return fmt.Sprintf("%s%s%06d", prefix, s.now().Format("20060102"), s.bizSeq)
+}
+
+// writeResult 写入成功响应
+func (s *Server) writeResult(w http.ResponseWriter, data any) {
+	s.writeJSON(w, http.StatusOK, map[string]any{
+		"code":     http.StatusOK,
+		"err_code": "",
+		"success":  true,
+		"message":  "success",
+		"data":     data,
+	})
+}
+
+// writeError 写入错误响应；业务错误时 httpStatus 为 200，code 为业务状态码
+func (s *Server) writeError(w http.ResponseWriter, httpStatus, code int, errCode, message string) {
+	s.writeJSON(w, httpStatus, map[string]any{
+		"code":     code,
+		"err_code": errCode,
+		"success":  false,
+		"message":  message,
+		"data":     nil,
+	})
+}
+
+// writeJSON 按当前响应格式写入
+func (s *Server) writeJSON(w http.ResponseWriter, httpStatus int, result map[string]any) {
+	var payload any = result
+	if s.wrapped {
+		payload = map[string]any{"result": result}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+// randomHex 生成 n 字节随机数的十六进制字符串
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// chainKey 为模拟账户生成确定性的私钥：私钥为 SHA-256(seed)。
+// 企业账户的 seed 为 "enterprise:" + DefaultAccessId，用户为 "user:" + open_id
+func chainKey(seed string) *keys.PrivateKey {
+	sum := sha256.Sum256([]byte(seed))
+	key, err := keys.ParsePrivateKey(sum[:])
+	if err != nil {
+		// SHA-256 结果落在 [1, N-1] 之外的概率可忽略
+		panic(fmt.Sprintf("junyoutest: chain key for %q: %v", seed, err))
+	}
+	return key
+}
+
+// chainAddress 模拟账户的链上地址：由 chainKey(seed) 的公钥按 keys.PublicKey.Address 推导（以太坊方式，假设与真实规则一致）
+func chainAddress(seed string) string {
+	return chainKey(seed).PublicKey().Address()
+}
@@ -0,0 +1,202 @@
+package junyoutest_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+	"github.com/junyouava/junyou-sdk-go/keys"
+)
+
+func newClient(t *testing.T, srv *junyoutest.Server) *junyousdk.Client {
+	t.Helper()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	reg, err := client.API().Register(&junyousdk.RegisterInfo{PhoneNumber: "13800138000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again := srv.AddUser("13800138000"); again != reg.Data {
+		t.Fatalf("AddUser for registered phone = %s, want %s", again, reg.Data)
+	}
+	if srv.UserAddress(reg.Data) == "" {
+		t.Fatal("registered user has no address")
+	}
+
+	login, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: reg.Data})
+	if err != nil || login.Data == "" {
+		t.Fatalf("AuthLogin = %+v, %v", login, err)
+	}
+	_, err = client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: "unknown"})
+	var apiErr *junyousdk.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Fatalf("AuthLogin unknown open_id: err = %v, want business error", err)
+	}
+}
+
+func TestRejectsBadSignature(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+junyousdk.APIPathRegister, "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unsigned request status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestGOCRewardLedger(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	openId := srv.AddUser("13800138000")
+
+	login, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: openId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pre, err := client.API().PreRewardGOCMessage(junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("1.50")}, login.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := pre.Data
+	if msg.From != srv.EnterpriseAddress || msg.To != srv.UserAddress(openId) || msg.BizType != junyoutest.BizTypeGOCReward {
+		t.Fatalf("message = %+v", msg)
+	}
+	if string(srv.PreSubmitMessage(msg.BizNo)) != string(pre.RawData) {
+		t.Fatalf("PreSubmitMessage = %s, want %s", srv.PreSubmitMessage(msg.BizNo), pre.RawData)
+	}
+
+	// 同一 Token 不能再次预提交
+	_, err = client.API().PreRewardGOC(junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("1")}, login.Data)
+	if !errors.Is(err, junyousdk.ErrOpenAuthInvalid) {
+		t.Fatalf("reused token: err = %v, want ErrOpenAuthInvalid", err)
+	}
+
+	commit := junyousdk.CommitGOCRewardRequest{BizNo: msg.BizNo, Message: string(pre.RawData), PublicKey: "04ab", DerHex: "3006"}
+	if _, err := client.API().RewardGOC(commit); err != nil {
+		t.Fatal(err)
+	}
+	if !srv.Committed(msg.BizNo) || srv.GOCBalance(openId) != "1.5" {
+		t.Fatalf("committed = %v, balance = %s", srv.Committed(msg.BizNo), srv.GOCBalance(openId))
+	}
+	if _, err := client.API().RewardGOC(commit); !errors.Is(err, junyousdk.ErrDuplicateBizNo) {
+		t.Fatalf("second commit: err = %v, want ErrDuplicateBizNo", err)
+	}
+}
+
+func TestUnwrappedResponses(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	srv.SetWrapped(false)
+	client := newClient(t, srv)
+
+	reg, err := client.API().Register(&junyousdk.RegisterInfo{PhoneNumber: "13800138000"})
+	if err != nil || reg.Data == "" {
+		t.Fatalf("Register = %+v, %v", reg, err)
+	}
+	last := srv.Requests()[len(srv.Requests())-1]
+	if last.Path != junyousdk.APIPathRegister {
+		t.Fatalf("last request path = %s", last.Path)
+	}
+}
+
+func TestAddTransaction(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	fixed := time.Date(2024, 3, 15, 8, 0, 0, 0, time.UTC)
+	srv.SetClock(junyousdk.ClockFunc(func() time.Time { return fixed }))
+
+	if err := srv.AddTransaction(junyoutest.Transaction{BizNo: "B1", Amount: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.AddTransaction(junyoutest.Transaction{BizNo: "B2", CreatedAt: "yesterday"}); err == nil {
+		t.Fatal("AddTransaction with invalid created_at: err = nil")
+	}
+	txs := srv.Transactions()
+	if len(txs) != 1 || txs[0].CreatedAt != fixed.Format(time.RFC3339) {
+		t.Fatalf("transactions = %+v", txs)
+	}
+	data, _ := json.Marshal(txs[0])
+	if !json.Valid(data) {
+		t.Fatal("transaction does not marshal")
+	}
+}
+
+// TestConcurrentClockAndTransactions 在 -race 下检查 SetClock 与 AddTransaction 并发时的数据竞争
+func TestConcurrentClockAndTransactions(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			srv.SetClock(junyousdk.SystemClock)
+		}()
+		go func() {
+			defer wg.Done()
+			if err := srv.AddTransaction(junyoutest.Transaction{BizNo: "B"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(srv.Transactions()); n != 4 {
+		t.Fatalf("transactions = %d, want 4", n)
+	}
+}
+
+func TestChainAddresses(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+
+	// 企业地址即 EnterpriseSigner 私钥推导的地址
+	if got := srv.EnterpriseSigner().Address(); !keys.EqualAddress(got, srv.EnterpriseAddress) {
+		t.Fatalf("EnterpriseSigner().Address() = %s, want %s", got, srv.EnterpriseAddress)
+	}
+	enterprise := sha256.Sum256([]byte("enterprise:" + junyoutest.DefaultAccessId))
+	if got := addressOf(t, enterprise[:]); got != srv.EnterpriseAddress {
+		t.Fatalf("enterprise address = %s, want key-derived %s", srv.EnterpriseAddress, got)
+	}
+
+	// 用户地址由 SHA-256("user:" + open_id) 私钥推导
+	openId := srv.AddUser("13800138000")
+	user := sha256.Sum256([]byte("user:" + openId))
+	if got := addressOf(t, user[:]); got != srv.UserAddress(openId) {
+		t.Fatalf("user address = %s, want key-derived %s", srv.UserAddress(openId), got)
+	}
+	if other := srv.UserAddress(srv.AddUser("13800138001")); other == srv.UserAddress(openId) {
+		t.Fatal("users share an address")
+	}
+}
+
+// addressOf 返回私钥对应的链上地址
+func addressOf(t *testing.T, privateKey []byte) string {
+	t.Helper()
+	key, err := keys.ParsePrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key.PublicKey().Address()
+}
@@ -0,0 +1,26 @@
+package keys
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Address 按以太坊方式由公钥推导地址：对未压缩公钥去掉 04 前缀后的 X || Y 做 Keccak-256，
+// 取后 20 字节，输出 0x 开头的小写十六进制。
+// 接口文档未说明链上地址的推导规则，这里假设与以太坊相同，使用前应与服务端返回的 from、to 核对（用 EqualAddress 忽略大小写）
+func (k *PublicKey) Address() string {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(k.Uncompressed()[1:])
+	return "0x" + hex.EncodeToString(h.Sum(nil)[12:])
+}
+
+// EqualAddress 比较两个链上地址，忽略 0x 前缀与十六进制大小写（含 EIP-55 校验和大小写）
+func EqualAddress(a, b string) bool {
+	trim := func(s string) string {
+		s = strings.TrimSpace(s)
+		return strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	}
+	return strings.EqualFold(trim(a), trim(b))
+}
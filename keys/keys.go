@@ -0,0 +1,326 @@
+// Package keys secp256k1 密钥与签名的格式转换：
+// 公钥的压缩 / 未压缩十六进制与 PEM，私钥的十六进制与 PEM（SEC1、PKCS#8），
+// 签名的 DER 与 r||s 原始格式（可用 Signature.Normalize 归一化为 low-S），以及由公钥推导链上地址。
+//
+// CommitGOCRewardRequest、CommitEWTReleaseByPartnerRequest 的 PublicKey 取 PublicKey.UncompressedHex，
+// DerHex 取 Signature.DERHex。
+//
+// 曲线运算、ECDSA 签名与验签使用 github.com/decred/dcrd/dcrec/secp256k1/v4（常量时间实现）。
+// 签名与验签的摘要为 message 的 SHA-256：Open API 文档未说明链上使用的摘要算法，
+// SHA-256 是 secp256k1 DER 签名的常见约定（与 openssl dgst -sha256 -sign 一致），接入前应与平台确认。
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// 错误
+var (
+	// ErrInvalidPublicKey 公钥格式错误或不在 secp256k1 曲线上
+	ErrInvalidPublicKey = errors.New("keys: invalid secp256k1 public key")
+	// ErrInvalidPrivateKey 私钥格式错误或不在 secp256k1 有效范围内
+	ErrInvalidPrivateKey = errors.New("keys: invalid secp256k1 private key")
+	// ErrUnsupportedCurve PEM 密钥不是 secp256k1 曲线
+	ErrUnsupportedCurve = errors.New("keys: key curve is not secp256k1")
+	// ErrInvalidSignature 签名格式错误
+	ErrInvalidSignature = errors.New("keys: invalid signature")
+)
+
+// ASN.1 对象标识
+var (
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// decodeHex 解码十六进制，忽略首尾空白与 0x 前缀
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	return hex.DecodeString(s)
+}
+
+// PublicKey secp256k1 公钥
+type PublicKey struct {
+	key *secp256k1.PublicKey
+}
+
+// ParsePublicKey 解析压缩（33 字节，02/03 开头）或未压缩（65 字节，04 开头）公钥，并校验点在曲线上
+func ParsePublicKey(b []byte) (*PublicKey, error) {
+	// 只接受标准格式，不接受 06/07 开头的混合格式
+	switch {
+	case len(b) == 33 && (b[0] == 0x02 || b[0] == 0x03):
+	case len(b) == 65 && b[0] == 0x04:
+	default:
+		return nil, fmt.Errorf("%w: %d bytes", ErrInvalidPublicKey, len(b))
+	}
+	key, err := secp256k1.ParsePubKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPublicKey, err)
+	}
+	return &PublicKey{key: key}, nil
+}
+
+// ParsePublicKeyHex 解析压缩或未压缩公钥十六进制，可带 0x 前缀
+func ParsePublicKeyHex(s string) (*PublicKey, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPublicKey, err)
+	}
+	return ParsePublicKey(b)
+}
+
+// subjectPublicKeyInfo X.509 公钥结构（RFC 5480）
+type subjectPublicKeyInfo struct {
+	Algorithm pkcs8Algorithm
+	PublicKey asn1.BitString
+}
+
+// ParsePublicKeyPEM 解析 PEM 公钥（PUBLIC KEY），曲线须为 secp256k1；
+// 也接受私钥 PEM（EC PRIVATE KEY、PRIVATE KEY），返回其对应的公钥。openssl 输出中的 EC PARAMETERS 块会被跳过
+func ParsePublicKeyPEM(pemBytes []byte) (*PublicKey, error) {
+	rest := pemBytes
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, fmt.Errorf("%w: no public key PEM block found", ErrInvalidPublicKey)
+		}
+		switch block.Type {
+		case "PUBLIC KEY":
+			var spki subjectPublicKeyInfo
+			if rest, err := asn1.Unmarshal(block.Bytes, &spki); err != nil || len(rest) != 0 {
+				return nil, fmt.Errorf("%w: malformed PUBLIC KEY block", ErrInvalidPublicKey)
+			}
+			if err := checkAlgorithm(spki.Algorithm); err != nil {
+				return nil, err
+			}
+			return ParsePublicKey(spki.PublicKey.RightAlign())
+		case "EC PRIVATE KEY", "PRIVATE KEY":
+			key, err := parsePrivateKeyBlock(block)
+			if err != nil {
+				return nil, err
+			}
+			return key.PublicKey(), nil
+		}
+	}
+}
+
+// Uncompressed 65 字节未压缩公钥 04 || X || Y
+func (k *PublicKey) Uncompressed() []byte {
+	return k.key.SerializeUncompressed()
+}
+
+// UncompressedHex 未压缩公钥小写十六进制（130 个字符），即提交请求的 public_key
+func (k *PublicKey) UncompressedHex() string {
+	return hex.EncodeToString(k.Uncompressed())
+}
+
+// Compressed 33 字节压缩公钥 02/03 || X
+func (k *PublicKey) Compressed() []byte {
+	return k.key.SerializeCompressed()
+}
+
+// CompressedHex 压缩公钥小写十六进制（66 个字符）
+func (k *PublicKey) CompressedHex() string {
+	return hex.EncodeToString(k.Compressed())
+}
+
+// PEM 编码为 PUBLIC KEY PEM（X.509 SubjectPublicKeyInfo，未压缩点）
+func (k *PublicKey) PEM() ([]byte, error) {
+	curve, err := asn1.Marshal(oidSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	point := k.Uncompressed()
+	der, err := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkcs8Algorithm{Algorithm: oidECPublicKey, Parameters: asn1.RawValue{FullBytes: curve}},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// Equal 是否为同一公钥
+func (k *PublicKey) Equal(o *PublicKey) bool {
+	return o != nil && k.key.IsEqual(o.key)
+}
+
+// VerifyMessage 校验签名：对 message 做 SHA-256 摘要后验证 ECDSA 签名，high-S 与 low-S 签名均接受
+func (k *PublicKey) VerifyMessage(message []byte, sig Signature) bool {
+	if sig.r.IsZero() || sig.s.IsZero() {
+		return false
+	}
+	digest := sha256.Sum256(message)
+	return ecdsa.NewSignature(&sig.r, &sig.s).Verify(digest[:], k.key)
+}
+
+// String 输出未压缩公钥十六进制
+func (k *PublicKey) String() string {
+	return k.UncompressedHex()
+}
+
+// PrivateKey secp256k1 私钥；String 只输出公钥，避免私钥经 fmt 打印
+type PrivateKey struct {
+	key *secp256k1.PrivateKey
+	pub *PublicKey
+}
+
+// ParsePrivateKey 由 32 字节私钥创建，值须在 [1, N-1] 内
+func ParsePrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != 32 {
+		return nil, fmt.Errorf("%w: expected 32 bytes, got %d", ErrInvalidPrivateKey, len(b))
+	}
+	var d secp256k1.ModNScalar
+	if overflow := d.SetByteSlice(b); overflow || d.IsZero() {
+		return nil, fmt.Errorf("%w: out of range [1, N-1]", ErrInvalidPrivateKey)
+	}
+	return newPrivateKey(secp256k1.NewPrivateKey(&d)), nil
+}
+
+// newPrivateKey 包装 secp256k1 私钥并缓存公钥
+func newPrivateKey(key *secp256k1.PrivateKey) *PrivateKey {
+	return &PrivateKey{key: key, pub: &PublicKey{key: key.PubKey()}}
+}
+
+// ParsePrivateKeyHex 由十六进制私钥（64 个字符，可带 0x 前缀）创建
+func ParsePrivateKeyHex(s string) (*PrivateKey, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+	return ParsePrivateKey(b)
+}
+
+// ParsePrivateKeyPEM 解析 PEM 私钥，支持 SEC1（EC PRIVATE KEY）与 PKCS#8（PRIVATE KEY），
+// 曲线须为 secp256k1；openssl 输出中的 EC PARAMETERS 块会被跳过
+func ParsePrivateKeyPEM(pemBytes []byte) (*PrivateKey, error) {
+	rest := pemBytes
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, fmt.Errorf("%w: no private key PEM block found", ErrInvalidPrivateKey)
+		}
+		if block.Type == "EC PRIVATE KEY" || block.Type == "PRIVATE KEY" {
+			return parsePrivateKeyBlock(block)
+		}
+	}
+}
+
+// GeneratePrivateKey 使用 crypto/rand 随机生成私钥
+func GeneratePrivateKey() (*PrivateKey, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+	return newPrivateKey(key), nil
+}
+
+// Bytes 32 字节私钥
+func (k *PrivateKey) Bytes() []byte {
+	return k.key.Serialize()
+}
+
+// PublicKey 对应的公钥
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return k.pub
+}
+
+// SignMessage 签名：对 message 做 SHA-256 摘要，按 RFC 6979 生成确定性 k，输出的 s 为 low-S
+func (k *PrivateKey) SignMessage(message []byte) (Signature, error) {
+	digest := sha256.Sum256(message)
+	sig := ecdsa.Sign(k.key, digest[:])
+	return Signature{r: sig.R(), s: sig.S()}, nil
+}
+
+// String 仅输出公钥
+func (k *PrivateKey) String() string {
+	return "PrivateKey(" + k.pub.UncompressedHex() + ")"
+}
+
+// GoString 同 String，避免 %#v 打印私钥
+func (k *PrivateKey) GoString() string {
+	return k.String()
+}
+
+// ecPrivateKey SEC1 私钥结构（RFC 5915）
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8PrivateKey PKCS#8 私钥结构（RFC 5208）
+type pkcs8PrivateKey struct {
+	Version    int
+	Algorithm  pkcs8Algorithm
+	PrivateKey []byte
+}
+
+// pkcs8Algorithm 算法标识，EC 密钥的参数为曲线 OID
+type pkcs8Algorithm struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+// checkAlgorithm 检查算法标识为 secp256k1 EC 密钥
+func checkAlgorithm(algorithm pkcs8Algorithm) error {
+	if !algorithm.Algorithm.Equal(oidECPublicKey) {
+		return fmt.Errorf("%w: not an EC key (%s)", ErrUnsupportedCurve, algorithm.Algorithm)
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(algorithm.Parameters.FullBytes, &curve); err != nil {
+		return fmt.Errorf("%w: missing named curve", ErrUnsupportedCurve)
+	}
+	if !curve.Equal(oidSecp256k1) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedCurve, curve)
+	}
+	return nil
+}
+
+// parsePrivateKeyBlock 解析 EC PRIVATE KEY 或 PRIVATE KEY 块
+func parsePrivateKeyBlock(block *pem.Block) (*PrivateKey, error) {
+	if block.Type == "EC PRIVATE KEY" {
+		return parseSEC1PrivateKey(block.Bytes)
+	}
+	var key pkcs8PrivateKey
+	if _, err := asn1.Unmarshal(block.Bytes, &key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+	if err := checkAlgorithm(key.Algorithm); err != nil {
+		return nil, err
+	}
+	return parseSEC1PrivateKey(key.PrivateKey)
+}
+
+// parseSEC1PrivateKey 解析 SEC1 DER 私钥；未声明曲线时按 secp256k1 处理
+func parseSEC1PrivateKey(der []byte) (*PrivateKey, error) {
+	var key ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+	if key.Version != 1 {
+		return nil, fmt.Errorf("%w: unsupported EC private key version %d", ErrInvalidPrivateKey, key.Version)
+	}
+	if len(key.NamedCurveOID) > 0 && !key.NamedCurveOID.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurve, key.NamedCurveOID)
+	}
+	if len(key.PrivateKey) > 32 {
+		return nil, fmt.Errorf("%w: private key too long", ErrInvalidPrivateKey)
+	}
+	padded := make([]byte, 32)
+	copy(padded[32-len(key.PrivateKey):], key.PrivateKey)
+	return ParsePrivateKey(padded)
+}
@@ -0,0 +1,309 @@
+package keys_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/junyouava/junyou-sdk-go/keys"
+)
+
+// testdata 由 openssl 生成：
+//
+//	openssl ecparam -name secp256k1 -genkey -out sec1.pem
+//	openssl pkcs8 -topk8 -nocrypt -in sec1.pem -out pkcs8.pem
+//	openssl ec -in sec1.pem -pubout -out public.pem
+//	printf 'hello chain' | openssl dgst -sha256 -sign sec1.pem | xxd -p -c 256  # 多次执行，结果见 openssl_sigs.txt
+const (
+	testdataMessage       = "hello chain"
+	testdataCompressedHex = "02fdc37a192ed66f325a68308a4c4be72b85ceb7830f6fd54637f59a57309caa3b"
+	testdataPrivateHex    = "946f29bd204ee9d2c546b2ef0b04b639ad270c164a2442864fd5c0c40bc8d3f8"
+)
+
+const (
+	// 基点 G 的未压缩编码（SEC 2 §2.4.1）
+	generatorHex = "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+	// 曲线阶 N
+	orderHex = "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func scalarHex(v int64) string {
+	return new(big.Int).SetInt64(v).Text(16)
+}
+
+func TestPublicKeyOfOneIsGenerator(t *testing.T) {
+	key, err := keys.ParsePrivateKeyHex(strings.Repeat("0", 63) + "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := key.PublicKey().UncompressedHex(); got != generatorHex {
+		t.Fatalf("pubkey(1) = %s, want G = %s", got, generatorHex)
+	}
+	if got := key.PublicKey().CompressedHex(); got != "02"+generatorHex[2:66] {
+		t.Fatalf("compressed pubkey(1) = %s", got)
+	}
+}
+
+// TestSignRFC6979 RFC 6979 确定性签名的公开测试向量：私钥 1，message "Satoshi Nakamoto"，SHA-256 摘要
+func TestAddressKnownAnswers(t *testing.T) {
+	tests := []struct {
+		privateHex, want string
+	}{
+		{strings.Repeat("0", 63) + "1", "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf"},
+		{"4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"},
+	}
+	for _, tt := range tests {
+		key, err := keys.ParsePrivateKeyHex(tt.privateHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := key.PublicKey().Address()
+		if got != strings.ToLower(tt.want) {
+			t.Errorf("Address(%s) = %s, want %s", tt.privateHex, got, strings.ToLower(tt.want))
+		}
+		if !keys.EqualAddress(got, tt.want) || !keys.EqualAddress(got, "0X"+strings.ToUpper(got[2:])) {
+			t.Errorf("EqualAddress(%s, %s) = false", got, tt.want)
+		}
+	}
+	if keys.EqualAddress("0x7e5f4552091a69125d5dfcb7b8c2659029395bdf", "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23") {
+		t.Error("different addresses compare equal")
+	}
+}
+
+func TestSignRFC6979(t *testing.T) {
+	key, err := keys.ParsePrivateKeyHex(strings.Repeat("0", 63) + "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := key.SignMessage([]byte("Satoshi Nakamoto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		wantR = "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8"
+		wantS = "2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"
+	)
+	if got := sig.R().Text(16); got != wantR {
+		t.Errorf("r = %s, want %s", got, wantR)
+	}
+	if got := sig.S().Text(16); got != wantS {
+		t.Errorf("s = %s, want %s", got, wantS)
+	}
+	wantDER := "3045022100" + wantR + "0220" + wantS
+	if got := sig.DERHex(); got != wantDER {
+		t.Errorf("DER = %s, want %s", got, wantDER)
+	}
+	if !sig.IsLowS() {
+		t.Error("signature is not low-S")
+	}
+	if !key.PublicKey().VerifyMessage([]byte("Satoshi Nakamoto"), sig) {
+		t.Error("signature does not verify")
+	}
+}
+
+func TestParsePrivateKeyRange(t *testing.T) {
+	n, _ := new(big.Int).SetString(orderHex, 16)
+	nMinus1 := new(big.Int).Sub(n, big.NewInt(1)).Text(16)
+	tests := []struct {
+		hex string
+		ok  bool
+	}{
+		{strings.Repeat("0", 64), false},
+		{orderHex, false},
+		{strings.Repeat("f", 64), false},
+		{nMinus1, true},
+		{"0x" + testdataPrivateHex, true},
+		{testdataPrivateHex[:62], false},
+		{"zz", false},
+	}
+	for _, tt := range tests {
+		_, err := keys.ParsePrivateKeyHex(tt.hex)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParsePrivateKeyHex(%s) err = %v, want ok = %v", tt.hex, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, keys.ErrInvalidPrivateKey) {
+			t.Errorf("ParsePrivateKeyHex(%s) err = %v, want ErrInvalidPrivateKey", tt.hex, err)
+		}
+	}
+}
+
+func TestParsePEM(t *testing.T) {
+	sec1, err := keys.ParsePrivateKeyPEM(readTestdata(t, "sec1.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := keys.ParsePrivateKeyPEM(readTestdata(t, "pkcs8.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sec1.Bytes(), pkcs8.Bytes()) {
+		t.Fatal("SEC1 and PKCS#8 PEM decode to different keys")
+	}
+	if got := sec1.PublicKey().CompressedHex(); got != testdataCompressedHex {
+		t.Fatalf("compressed public key = %s, want %s", got, testdataCompressedHex)
+	}
+
+	pub, err := keys.ParsePublicKeyPEM(readTestdata(t, "public.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Equal(sec1.PublicKey()) {
+		t.Fatal("public.pem does not match the private key")
+	}
+	fromPriv, err := keys.ParsePublicKeyPEM(readTestdata(t, "sec1.pem"))
+	if err != nil || !fromPriv.Equal(pub) {
+		t.Fatalf("ParsePublicKeyPEM(private key) = %v, %v", fromPriv, err)
+	}
+
+	// PEM() 与 openssl 输出逐字节一致
+	out, err := pub.PEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, readTestdata(t, "public.pem")) {
+		t.Fatalf("PEM() = %s\nwant %s", out, readTestdata(t, "public.pem"))
+	}
+
+	p256 := readTestdata(t, "p256_public.pem") // openssl ecparam -name prime256v1 -genkey -noout | openssl ec -pubout
+	if _, err := keys.ParsePublicKeyPEM(p256); !errors.Is(err, keys.ErrUnsupportedCurve) {
+		t.Fatalf("P-256 key: err = %v, want ErrUnsupportedCurve", err)
+	}
+}
+
+// TestVerifyOpenSSLSignatures openssl 输出的签名（含 high-S）均能通过验签，归一化后仍有效
+func TestVerifyOpenSSLSignatures(t *testing.T) {
+	pub, err := keys.ParsePublicKeyHex(testdataCompressedHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte(testdataMessage)
+
+	var highS int
+	scanner := bufio.NewScanner(bytes.NewReader(readTestdata(t, "openssl_sigs.txt")))
+	for scanner.Scan() {
+		derHex := strings.TrimSpace(scanner.Text())
+		if derHex == "" {
+			continue
+		}
+		sig, err := keys.ParseDERHex(derHex)
+		if err != nil {
+			t.Fatalf("ParseDERHex(%s): %v", derHex, err)
+		}
+		if sig.DERHex() != derHex {
+			t.Errorf("DER round trip = %s, want %s", sig.DERHex(), derHex)
+		}
+		if !pub.VerifyMessage(message, sig) {
+			t.Errorf("openssl signature %s does not verify", derHex)
+		}
+		if !sig.IsLowS() {
+			highS++
+		}
+		normalized := sig.Normalize()
+		if !normalized.IsLowS() || !pub.VerifyMessage(message, normalized) {
+			t.Errorf("normalized signature of %s invalid", derHex)
+		}
+		if pub.VerifyMessage([]byte("hello chain!"), sig) {
+			t.Errorf("signature %s verifies a different message", derHex)
+		}
+	}
+	if highS == 0 {
+		t.Fatal("fixtures contain no high-S signature")
+	}
+}
+
+func TestParsePublicKeyRejects(t *testing.T) {
+	tests := []string{
+		"",
+		generatorHex[:128],                       // 截断
+		"05" + generatorHex[2:],                  // 错误前缀
+		"06" + generatorHex[2:],                  // 混合格式
+		"04" + generatorHex[2:66] + scalarHex(1), // 不在曲线上
+		"02" + strings.Repeat("f", 64),           // x 超出域
+	}
+	for _, s := range tests {
+		if _, err := keys.ParsePublicKeyHex(s); !errors.Is(err, keys.ErrInvalidPublicKey) {
+			t.Errorf("ParsePublicKeyHex(%q) err = %v, want ErrInvalidPublicKey", s, err)
+		}
+	}
+}
+
+func TestSignatureEncodings(t *testing.T) {
+	// openssl_sigs.txt 第 4 行为 high-S
+	const highS = "304502206b2208705909efc3fc5a61cc2b2b861c38fa02a6c477fb3fff808d04cf536740022100b7144b22e56606631826538a56d223a1751a6ebd88b5c518085d74c7e2cec8ae"
+	sig, err := keys.ParseDERHex(highS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.IsLowS() {
+		t.Fatal("IsLowS = true for a high-S signature")
+	}
+
+	raw := sig.RawHex()
+	if len(raw) != 128 {
+		t.Fatalf("RawHex length = %d", len(raw))
+	}
+	der, err := keys.RawToDERHex(raw)
+	if err != nil || der != highS {
+		t.Fatalf("RawToDERHex = %s, %v; want original DER", der, err)
+	}
+	withV, err := keys.ParseRawHex(raw + "1b")
+	if err != nil || withV.DERHex() != highS {
+		t.Fatalf("ParseRawHex(r||s||v) = %v, %v", withV, err)
+	}
+
+	n, _ := new(big.Int).SetString(orderHex, 16)
+	if want := new(big.Int).Sub(n, sig.S()); sig.Normalize().S().Cmp(want) != 0 {
+		t.Fatalf("Normalize().S() = %x, want N - s = %x", sig.Normalize().S(), want)
+	}
+
+	for _, bad := range []string{
+		"3006020101020101" + "00",          // 尾随数据
+		"30070202000102010100",             // 非最小编码
+		"3006020100020101",                 // r = 0
+		"3026022100" + orderHex + "020101", // r = N
+	} {
+		if _, err := keys.ParseDERHex(bad); !errors.Is(err, keys.ErrInvalidSignature) {
+			t.Errorf("ParseDERHex(%s) err = %v, want ErrInvalidSignature", bad, err)
+		}
+	}
+	if _, err := keys.NewSignature(big.NewInt(0), big.NewInt(1)); !errors.Is(err, keys.ErrInvalidSignature) {
+		t.Errorf("NewSignature(0, 1) err = %v", err)
+	}
+	if _, err := keys.NewSignature(big.NewInt(1), n); !errors.Is(err, keys.ErrInvalidSignature) {
+		t.Errorf("NewSignature(1, N) err = %v", err)
+	}
+}
+
+func TestPrivateKeyStringHidesSecret(t *testing.T) {
+	key, err := keys.ParsePrivateKeyHex(testdataPrivateHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{key.String(), key.GoString()} {
+		if strings.Contains(s, testdataPrivateHex) {
+			t.Fatalf("String leaks private key: %s", s)
+		}
+	}
+	generated, err := keys.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, _ := generated.SignMessage([]byte("x"))
+	if !generated.PublicKey().VerifyMessage([]byte("x"), sig) {
+		t.Fatal("generated key signature does not verify")
+	}
+}
@@ -0,0 +1,141 @@
+package keys
+
+import (
+	"encoding/asn1"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// Signature secp256k1 ECDSA 签名 (r, s)。解析与编码均保留原始 s，不自动归一化：
+// 外部签名服务（如 openssl）可能输出 high-S 签名，验签时同样有效；需要 low-S 时调用 Normalize。
+// 须由 Parse* 或 NewSignature 创建，零值不可用。
+type Signature struct {
+	r, s secp256k1.ModNScalar
+}
+
+// NewSignature 由 r、s 创建签名，二者须在 [1, N-1] 内
+func NewSignature(r, s *big.Int) (Signature, error) {
+	var sig Signature
+	if !setScalar(&sig.r, r) || !setScalar(&sig.s, s) {
+		return Signature{}, fmt.Errorf("%w: r and s must be within [1, N-1]", ErrInvalidSignature)
+	}
+	return sig, nil
+}
+
+// setScalar 将 v 写入 scalar，v 须在 [1, N-1] 内
+func setScalar(scalar *secp256k1.ModNScalar, v *big.Int) bool {
+	if v == nil || v.Sign() <= 0 || v.BitLen() > 256 {
+		return false
+	}
+	overflow := scalar.SetByteSlice(v.Bytes())
+	return !overflow && !scalar.IsZero()
+}
+
+// ParseDER 严格解析 DER 签名（SEQUENCE { r INTEGER, s INTEGER }）：不允许尾随数据、非最小编码与超出 [1, N-1] 的 r、s
+func ParseDER(der []byte) (Signature, error) {
+	sig, err := ecdsa.ParseDERSignature(der)
+	if err != nil {
+		return Signature{}, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return Signature{r: sig.R(), s: sig.S()}, nil
+}
+
+// ParseDERHex 解析 DER 签名十六进制，可带 0x 前缀
+func ParseDERHex(s string) (Signature, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return Signature{}, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return ParseDER(b)
+}
+
+// ParseRaw 解析 64 字节 r||s 原始签名（r、s 各 32 字节大端）；
+// 65 字节时视为 r||s||v，忽略末尾的恢复标识 v
+func ParseRaw(b []byte) (Signature, error) {
+	if len(b) == 65 {
+		b = b[:64]
+	}
+	if len(b) != 64 {
+		return Signature{}, fmt.Errorf("%w: raw signature must be 64 bytes, got %d", ErrInvalidSignature, len(b))
+	}
+	return NewSignature(new(big.Int).SetBytes(b[:32]), new(big.Int).SetBytes(b[32:]))
+}
+
+// ParseRawHex 解析 r||s 原始签名十六进制，可带 0x 前缀
+func ParseRawHex(s string) (Signature, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return Signature{}, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return ParseRaw(b)
+}
+
+// R 签名的 r
+func (sig Signature) R() *big.Int {
+	b := sig.r.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+// S 签名的 s（可能为 high-S）
+func (sig Signature) S() *big.Int {
+	b := sig.s.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+// IsLowS s 是否不大于 N/2
+func (sig Signature) IsLowS() bool {
+	return !sig.s.IsOverHalfOrder()
+}
+
+// Normalize 返回 low-S 形式：s > N/2 时取 N − s，签名对同一 message 与公钥仍然有效
+func (sig Signature) Normalize() Signature {
+	if sig.IsLowS() {
+		return sig
+	}
+	sig.s.Negate()
+	return sig
+}
+
+// derSignature DER 签名结构
+type derSignature struct {
+	R, S *big.Int
+}
+
+// DER 编码为 DER 签名，s 保持原值
+func (sig Signature) DER() []byte {
+	der, err := asn1.Marshal(derSignature{R: sig.R(), S: sig.S()})
+	if err != nil {
+		// asn1 编码两个正整数不会失败
+		panic(err)
+	}
+	return der
+}
+
+// DERHex DER 签名小写十六进制，即提交请求的 der_hex
+func (sig Signature) DERHex() string {
+	return hex.EncodeToString(sig.DER())
+}
+
+// Raw 编码为 64 字节 r||s，s 保持原值
+func (sig Signature) Raw() []byte {
+	r, s := sig.r.Bytes(), sig.s.Bytes()
+	return append(r[:], s[:]...)
+}
+
+// RawHex r||s 小写十六进制（128 个字符）
+func (sig Signature) RawHex() string {
+	return hex.EncodeToString(sig.Raw())
+}
+
+// RawToDERHex 将 r||s 原始签名十六进制转为 DER 十六进制，便于填入 der_hex；s 保持原值
+func RawToDERHex(rawHex string) (string, error) {
+	sig, err := ParseRawHex(rawHex)
+	if err != nil {
+		return "", err
+	}
+	return sig.DERHex(), nil
+}
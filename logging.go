@@ -0,0 +1,191 @@
+package junyousdk
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// redacted 脱敏后的占位值
+const redacted = "[REDACTED]"
+
+// redactedHeaders 日志中需要脱敏的请求头
+var redactedHeaders = map[string]bool{
+	http.CanonicalHeaderKey(HeaderSignature): true,
+	http.CanonicalHeaderKey(HeaderOpenAuth):  true,
+	"Authorization":                          true,
+}
+
+// redactedFields 日志中需要脱敏的请求体字段（JSON 键名）
+var redactedFields = map[string]bool{
+	"access_key":   true,
+	"signature":    true,
+	"open_auth":    true,
+	"der_hex":      true,
+	"phone_number": true,
+}
+
+// logInterceptor 内置日志拦截器：每次尝试记录一条日志（method、path、status、err_code、latency、attempt）。
+// 成功按 Config.LogLevel 记录，失败按 Config.ErrorLogLevel 记录；Debug 级别开启时附带脱敏后的 Header 与请求体。
+func logInterceptor(c *Client) Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			logger := c.config.Logger
+			start := time.Now()
+			resp, err := next(ctx, req)
+			latency := time.Since(start)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", req.APIPath),
+				slog.Int("attempt", req.Attempt),
+				slog.Duration("latency", latency),
+			}
+			failed := err != nil
+			if resp != nil {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+				failed = failed || resp.StatusCode != http.StatusOK
+				if resp.Result != nil {
+					attrs = append(attrs, slog.Int("code", resp.Result.Code))
+					if resp.Result.ErrCode != "" {
+						attrs = append(attrs, slog.String("err_code", resp.Result.ErrCode))
+					}
+					failed = failed || resp.Result.Code != http.StatusOK
+				}
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+			if logger.Enabled(ctx, slog.LevelDebug) {
+				attrs = append(attrs,
+					slog.Any("header", redactHeader(req.Header)),
+					slog.Any("body", redactBody(req.Body)),
+				)
+			}
+
+			level := logLevel(c.config.LogLevel, slog.LevelInfo)
+			if failed {
+				level = logLevel(c.config.ErrorLogLevel, slog.LevelWarn)
+			}
+			logger.LogAttrs(ctx, level, "junyousdk request", attrs...)
+			return resp, err
+		}
+	}
+}
+
+// logLevel 返回 leveler 对应的级别，nil 时返回默认级别
+func logLevel(leveler slog.Leveler, fallback slog.Level) slog.Level {
+	if leveler == nil {
+		return fallback
+	}
+	return leveler.Level()
+}
+
+// redactHeader 复制 Header 并脱敏敏感字段
+func redactHeader(header http.Header) http.Header {
+	out := make(http.Header, len(header))
+	for k, v := range header {
+		if redactedHeaders[http.CanonicalHeaderKey(k)] {
+			out[k] = []string{redacted}
+			continue
+		}
+		out[k] = append([]string(nil), v...)
+	}
+	return out
+}
+
+// redactBody 将请求体转为通用 JSON 结构并脱敏敏感字段；无法序列化时只返回占位值
+func redactBody(body any) any {
+	if body == nil {
+		return nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return redacted
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return redacted
+	}
+	return redactValue(v)
+}
+
+// redactValue 递归脱敏 map 中的敏感键
+func redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			if redactedFields[k] {
+				val[k] = redacted
+				continue
+			}
+			val[k] = redactValue(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// LogValue 实现 slog.LogValuer，AccessKey 不会出现在日志中
+func (c *Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("access_id", c.AccessId),
+		slog.String("access_key", redacted),
+		slog.String("version", c.Version),
+		slog.String("address", c.Address),
+	)
+}
+
+// LogValue 实现 slog.LogValuer，隐藏手机号
+func (r RegisterInfo) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("phone_number", redacted))
+}
+
+// LogValue 实现 slog.LogValuer，隐藏签名值
+func (s Signature) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("access_id", s.AccessId),
+		slog.String("signature", redacted),
+		slog.String("nonce", s.Nonce),
+		slog.String("timestamp", s.Timestamp),
+	)
+}
+
+// LogValue 实现 slog.LogValuer，隐藏签名值与 OpenAuth
+func (s SignatureWithOpenAuth) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("access_id", s.AccessId),
+		slog.String("signature", redacted),
+		slog.String("nonce", s.Nonce),
+		slog.String("timestamp", s.Timestamp),
+		slog.String("open_auth", redacted),
+	)
+}
+
+// LogValue 实现 slog.LogValuer，隐藏 DER 签名
+func (r CommitGOCRewardRequest) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("biz_no", r.BizNo),
+		slog.String("message", r.Message),
+		slog.String("public_key", r.PublicKey),
+		slog.String("der_hex", redacted),
+	)
+}
+
+// LogValue 实现 slog.LogValuer，隐藏 DER 签名
+func (r CommitEWTReleaseByPartnerRequest) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("biz_no", r.BizNo),
+		slog.String("message", r.Message),
+		slog.String("public_key", r.PublicKey),
+		slog.String("der_hex", redacted),
+	)
+}
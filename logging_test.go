@@ -0,0 +1,120 @@
+package junyousdk_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// logRecords 解析 JSONHandler 输出的每行日志
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestLoggingRedactsSecrets(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := junyousdk.NewClient(srv.Config().WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	openId := srv.AddUser("13800138000")
+	login, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: openId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.API().Register(&junyousdk.RegisterInfo{PhoneNumber: "13900139000"}); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = client.API().PreRewardGOC(junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("1")}, login.Data)
+	_, _ = client.API().RewardGOC(junyousdk.CommitGOCRewardRequest{BizNo: "GOC1", Message: "{}", PublicKey: "04ab", DerHex: "3044deadbeef"})
+
+	out := buf.String()
+	for _, secret := range []string{srv.AccessKey, login.Data, "13900139000", "3044deadbeef"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output contains secret %q", secret)
+		}
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 4 {
+		t.Fatalf("got %d log records, want 4", len(records))
+	}
+	first := records[0]
+	if first["msg"] != "junyousdk request" || first["path"] != junyousdk.APIPathAuthLogin || first["level"] != "INFO" {
+		t.Fatalf("first record = %v", first)
+	}
+	header, _ := first["header"].(map[string]any)
+	if sig, _ := header[junyousdk.HeaderSignature].([]any); len(sig) != 1 || sig[0] != "[REDACTED]" {
+		t.Fatalf("X-Signature = %v, want redacted", header[junyousdk.HeaderSignature])
+	}
+}
+
+func TestLoggingLevels(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	client, err := junyousdk.NewClient(srv.Config().
+		WithLogger(logger).
+		WithLogLevel(slog.LevelDebug, slog.LevelError))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.API().Register(&junyousdk.RegisterInfo{PhoneNumber: "13800138000"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.FailNext(junyousdk.APIPathRegister, http.StatusBadGateway)
+	_, _ = client.API().Register(&junyousdk.RegisterInfo{PhoneNumber: "13800138000"})
+
+	// 成功请求按 Debug 记录，低于 handler 的 Info 级别被过滤；失败请求按 Error 记录
+	records := logRecords(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("got %d log records, want 1", len(records))
+	}
+	if records[0]["level"] != "ERROR" || records[0]["status"] != float64(http.StatusBadGateway) {
+		t.Fatalf("record = %v", records[0])
+	}
+	if _, ok := records[0]["header"]; ok {
+		t.Fatal("header logged without Debug level enabled")
+	}
+}
+
+func TestConfigLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	config := junyousdk.DefaultConfig().WithAccessId("id").WithAccessKey("c2VjcmV0LWtleQ==")
+	logger.Info("config", "config", config, "commit", junyousdk.CommitGOCRewardRequest{BizNo: "GOC1", DerHex: "3045secret"})
+
+	out := buf.String()
+	if strings.Contains(out, "c2VjcmV0LWtleQ==") || strings.Contains(out, "3045secret") {
+		t.Fatalf("log output leaks secrets: %s", out)
+	}
+	if !strings.Contains(out, "config.access_id=id") || !strings.Contains(out, "commit.biz_no=GOC1") {
+		t.Fatalf("log output = %s", out)
+	}
+}
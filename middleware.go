@@ -0,0 +1,147 @@
+package junyousdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Request 经过拦截器链的请求
+type Request struct {
+	// Method HTTP 方法
+	Method string
+	// APIPath 请求路径（可含 query）
+	APIPath string
+	// Body 请求体（序列化前），在发送时才做 JSON 序列化，拦截器可替换
+	Body any
+	// Header 请求头；签名拦截器之后的拦截器看到的是最终签名后的 Header
+	Header http.Header
+	// Attempt 当前尝试次数（从 1 开始）
+	Attempt int
+}
+
+// Clone 复制请求，Header 深拷贝
+func (r *Request) Clone() *Request {
+	clone := *r
+	clone.Header = r.Header.Clone()
+	if clone.Header == nil {
+		clone.Header = make(http.Header)
+	}
+	return &clone
+}
+
+// Response 拦截器链返回的响应
+type Response struct {
+	// StatusCode HTTP 状态码
+	StatusCode int
+	// Header 响应头
+	Header http.Header
+	// RawBody 原始响应体
+	RawBody []byte
+	// Result 解析后的结果，Data 保留原始 JSON；响应体为空或无法解析时为 nil
+	Result *Result[json.RawMessage]
+}
+
+// Handler 处理一次请求
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Interceptor 拦截器，包装下一个 Handler。
+// 可在调用 next 前后读取或修改 Request/Response，也可不调用 next 直接返回（短路）。
+type Interceptor func(next Handler) Handler
+
+// Use 追加拦截器，对之后经 DoRequest 发出的所有请求生效。
+// 调用顺序：重试 → 签名 → 日志（配置了 Config.Logger 时）→ 调用方拦截器（先添加的在外层）→ 发送 HTTP 请求。
+// 因此调用方拦截器看到的是已签名的 Header，且每次重试都会经过。
+func (c *Client) Use(interceptors ...Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+// handler 构建完整的拦截器链
+func (c *Client) handler() Handler {
+	c.mu.RLock()
+	interceptors := make([]Interceptor, 0, len(c.interceptors)+3)
+	interceptors = append(interceptors, retryInterceptor(c), signInterceptor(c))
+	if c.config.Logger != nil {
+		interceptors = append(interceptors, logInterceptor(c))
+	}
+	for i, interceptor := range c.interceptors {
+		interceptors = append(interceptors, guardInterceptor(i+1, interceptor))
+	}
+	c.mu.RUnlock()
+
+	h := Handler(c.transport)
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		h = interceptors[i](h)
+	}
+	return h
+}
+
+// guardInterceptor 包装 Use 添加的第 index 个拦截器（从 1 开始）：
+// 其返回 nil Response 且无错误时转为错误，指明是哪个拦截器，避免后续读取响应时空指针
+func guardInterceptor(index int, interceptor Interceptor) Interceptor {
+	return func(next Handler) Handler {
+		h := interceptor(next)
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := h(ctx, req)
+			if resp == nil && err == nil {
+				message := fmt.Sprintf("interceptor #%d added via Use returned a nil response", index)
+				return nil, &requestError{message, errors.New(message), false}
+			}
+			return resp, err
+		}
+	}
+}
+
+// signInterceptor 内置签名拦截器：生成认证 Header 并与请求已有 Header 合并（已有 Header 优先）
+func signInterceptor(c *Client) Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			header, err := c.auth.GenerateAuthHeader(req.Method, req.APIPath)
+			if err != nil {
+				return nil, &requestError{"failed to generate auth header", fmt.Errorf("failed to generate auth header: %w", err), false}
+			}
+			for k, v := range req.Header {
+				header[k] = v
+			}
+			req.Header = header
+			return next(ctx, req)
+		}
+	}
+}
+
+// retryInterceptor 内置重试拦截器：按 Config.RetryPolicy 重试，每次尝试都从原始请求复制，
+// 使签名拦截器重新生成 nonce 与时间戳
+func retryInterceptor(c *Client) Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			policy := c.config.RetryPolicy
+			retrySafe := isRetrySafe(req.Method, req.APIPath)
+
+			for attempt := 1; ; attempt++ {
+				attemptReq := req.Clone()
+				attemptReq.Attempt = attempt
+				resp, err := next(ctx, attemptReq)
+
+				var statusCode int
+				var header http.Header
+				if resp != nil {
+					statusCode, header = resp.StatusCode, resp.Header
+				}
+				wait, retry := policy.retryDelay(attempt, retrySafe, statusCode, header, err)
+				if !retry {
+					return resp, err
+				}
+				if sleepErr := sleepContext(ctx, wait); sleepErr != nil {
+					if err == nil {
+						err = sleepErr
+					}
+					return resp, err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package junyousdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+func TestUseOrderAndSignedHeader(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config().WithRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var order []string
+	var attempts []int
+	trace := func(name string) junyousdk.Interceptor {
+		return func(next junyousdk.Handler) junyousdk.Handler {
+			return func(ctx context.Context, req *junyousdk.Request) (*junyousdk.Response, error) {
+				if req.Header.Get(junyousdk.HeaderSignature) == "" {
+					t.Errorf("%s: request not signed before caller interceptor", name)
+				}
+				order = append(order, name+">")
+				resp, err := next(ctx, req)
+				order = append(order, "<"+name)
+				if name == "outer" {
+					attempts = append(attempts, req.Attempt)
+				}
+				return resp, err
+			}
+		}
+	}
+	client.Use(trace("outer"), trace("inner"))
+
+	openId := srv.AddUser("13800138000")
+	srv.FailNext(junyousdk.APIPathAuthLogin, http.StatusServiceUnavailable)
+	if _, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: openId}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"outer>", "inner>", "<inner", "<outer", "outer>", "inner>", "<inner", "<outer"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	if !reflect.DeepEqual(attempts, []int{1, 2}) {
+		t.Fatalf("attempts = %v, want [1 2]", attempts)
+	}
+}
+
+func TestUseShortCircuit(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.Use(func(next junyousdk.Handler) junyousdk.Handler {
+		return func(ctx context.Context, req *junyousdk.Request) (*junyousdk.Response, error) {
+			return &junyousdk.Response{
+				StatusCode: http.StatusOK,
+				Result: &junyousdk.Result[json.RawMessage]{
+					Code:    http.StatusOK,
+					Success: true,
+					Data:    json.RawMessage(`"stubbed"`),
+				},
+			}, nil
+		}
+	})
+
+	result, err := client.API().Register(&junyousdk.RegisterInfo{PhoneNumber: "13800138000"})
+	if err != nil || result.Data != "stubbed" {
+		t.Fatalf("Register = %+v, %v; want stubbed data", result, err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want 0", n)
+	}
+}
+
+func TestUseInterceptorError(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errBlocked := errors.New("blocked by policy")
+	client.Use(func(next junyousdk.Handler) junyousdk.Handler {
+		return func(ctx context.Context, req *junyousdk.Request) (*junyousdk.Response, error) {
+			if req.APIPath == junyousdk.APIPathGOCReward {
+				return nil, errBlocked
+			}
+			return next(ctx, req)
+		}
+	})
+
+	result, err := client.API().RewardGOC(junyousdk.CommitGOCRewardRequest{BizNo: "GOC1"})
+	if !errors.Is(err, errBlocked) {
+		t.Fatalf("err = %v, want errBlocked", err)
+	}
+	if result == nil || result.Success {
+		t.Fatalf("result = %+v, want non-nil failed result", result)
+	}
+}
+
+func TestUseNilResponse(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passThrough := func(next junyousdk.Handler) junyousdk.Handler { return next }
+	client.Use(passThrough, func(next junyousdk.Handler) junyousdk.Handler {
+		return func(ctx context.Context, req *junyousdk.Request) (*junyousdk.Response, error) {
+			return nil, nil
+		}
+	})
+
+	result, err := client.API().Register(&junyousdk.RegisterInfo{PhoneNumber: "13800138000"})
+	if err == nil || !strings.Contains(err.Error(), "interceptor #2") {
+		t.Fatalf("err = %v, want error naming interceptor #2", err)
+	}
+	if result == nil || result.Success || result.Code != http.StatusInternalServerError {
+		t.Fatalf("result = %+v, want sys error result", result)
+	}
+	if !strings.Contains(result.Message, "interceptor #2") {
+		t.Fatalf("message = %q, want it to name interceptor #2", result.Message)
+	}
+}
@@ -0,0 +1,191 @@
+package junyousdk
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// 响应模型：对应各接口 result.data 的结构。
+// 服务端新增或未在此声明的字段保留在各模型的 Extra 中（键为 JSON 字段名，值为原始 JSON），不会丢失。
+
+// EWTBalance 权证余额条目
+type EWTBalance struct {
+	OpenId  string `json:"open_id"` // 用户 OpenId
+	Balance Amount `json:"balance"` // 权证余额
+	// Extra 未声明的字段
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON 解析已知字段，其余字段保留在 Extra
+func (m *EWTBalance) UnmarshalJSON(data []byte) error {
+	type plain EWTBalance
+	return unmarshalWithExtra(data, (*plain)(m), &m.Extra)
+}
+
+// EWTBalancePage 权证余额分页
+// 对应接口: GET /api/open/v1/ewt/balance
+//
+// 接口文档未给出 data 的结构，List、Total、Page、PageSize、HasMore 及其 JSON 字段名均为 SDK 的假设。
+// 实际响应与假设不符时这些字段为零值，服务端返回的全部字段保留在 Extra，原始 data 见 Result.RawData。
+type EWTBalancePage struct {
+	List     []EWTBalance `json:"list"`               // 当前页数据
+	Total    int          `json:"total"`              // 总条数
+	Page     int          `json:"page"`               // 页码，从 1 开始
+	PageSize int          `json:"page_size"`          // 每页条数
+	HasMore  *bool        `json:"has_more,omitempty"` // 是否还有下一页；服务端未返回时为 nil
+	// Extra 未声明的字段
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON 解析已知字段，其余字段保留在 Extra
+func (m *EWTBalancePage) UnmarshalJSON(data []byte) error {
+	type plain EWTBalancePage
+	return unmarshalWithExtra(data, (*plain)(m), &m.Extra)
+}
+
+// EWTTransaction 权证交易明细条目
+type EWTTransaction struct {
+	BizNo           string `json:"biz_no"`           // 业务单号
+	OpenId          string `json:"open_id"`          // 用户 OpenId
+	TransactionType string `json:"transaction_type"` // 交易类型，如 in / out
+	BizType         string `json:"biz_type"`         // 业务类型
+	Amount          Amount `json:"amount"`           // 数量
+	CreatedAt       string `json:"created_at"`       // 创建时间
+	// Extra 未声明的字段
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON 解析已知字段，其余字段保留在 Extra
+func (m *EWTTransaction) UnmarshalJSON(data []byte) error {
+	type plain EWTTransaction
+	return unmarshalWithExtra(data, (*plain)(m), &m.Extra)
+}
+
+// EWTTransactionPage 权证交易明细分页
+// 对应接口: GET /api/open/v1/ewt/transaction_details
+//
+// 分页字段同 EWTBalancePage，均为 SDK 的假设；与实际响应不符时内容保留在 Extra。
+type EWTTransactionPage struct {
+	List     []EWTTransaction `json:"list"`               // 当前页数据
+	Total    int              `json:"total"`              // 总条数
+	Page     int              `json:"page"`               // 页码，从 1 开始
+	PageSize int              `json:"page_size"`          // 每页条数
+	HasMore  *bool            `json:"has_more,omitempty"` // 是否还有下一页；服务端未返回时为 nil
+	// Extra 未声明的字段
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON 解析已知字段，其余字段保留在 Extra
+func (m *EWTTransactionPage) UnmarshalJSON(data []byte) error {
+	type plain EWTTransactionPage
+	return unmarshalWithExtra(data, (*plain)(m), &m.Extra)
+}
+
+// GOCRewardMessage GOC 预提交返回的待上链转账消息；字段与预提交请求一致
+// 对应接口: POST /api/open/v1/goc/pre_reward
+type GOCRewardMessage struct {
+	From    string `json:"from"`     // 付款方（企业）链上地址
+	To      string `json:"to"`       // 收款方链上地址
+	Amount  Amount `json:"amount"`   // 金额
+	BizNo   string `json:"biz_no"`   // 业务单号，提交时原样回传
+	BizType string `json:"biz_type"` // 业务类型
+	BizDesc string `json:"biz_desc"` // 业务描述
+	// Extra 未声明的字段
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON 解析已知字段，其余字段保留在 Extra
+func (m *GOCRewardMessage) UnmarshalJSON(data []byte) error {
+	type plain GOCRewardMessage
+	return unmarshalWithExtra(data, (*plain)(m), &m.Extra)
+}
+
+// EWTReleaseMessage 权证释放预提交返回的待上链业务消息
+// 对应接口: POST /api/open/v1/ewt/pre_ewt_rbp_open
+//
+// 接口文档未给出该 data 的结构，除 biz_no 外的字段名按预提交请求与 GOC 消息推定，为 SDK 的假设；
+// 与实际响应不符时这些字段为空，服务端返回的全部字段保留在 Extra。
+type EWTReleaseMessage struct {
+	BizNo        string `json:"biz_no"`         // 业务单号，提交时原样回传
+	BizType      string `json:"biz_type"`       // 业务类型
+	From         string `json:"from"`           // 付款方链上地址
+	To           string `json:"to"`             // 收款方链上地址
+	OpenId       string `json:"open_id"`        // 接收方 OpenId
+	Amount       Amount `json:"amount"`         // 权证数量
+	Ratio        Ratio  `json:"ratio"`          // 总释放比例
+	Level1OpenId string `json:"level1_open_id"` // 一级合伙人 OpenId
+	Level1Ratio  Ratio  `json:"level1_ratio"`   // 一级合伙人分配比例
+	Level2OpenId string `json:"level2_open_id"` // 二级合伙人 OpenId
+	Level2Ratio  Ratio  `json:"level2_ratio"`   // 二级合伙人分配比例
+	// Extra 未声明的字段
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON 解析已知字段，其余字段保留在 Extra
+func (m *EWTReleaseMessage) UnmarshalJSON(data []byte) error {
+	type plain EWTReleaseMessage
+	return unmarshalWithExtra(data, (*plain)(m), &m.Extra)
+}
+
+// CommitReceipt 提交上链回执
+// 对应接口: POST /api/open/v1/goc/reward、POST /api/open/v1/ewt/commit_ewt_rbp
+//
+// 接口文档未给出提交响应 data 的结构，BizNo、TxHash、Status 及其 JSON 字段名均为 SDK 的假设；
+// 与实际响应不符时这些字段为空，服务端返回的全部字段保留在 Extra，原始 data 见 Result.RawData。
+type CommitReceipt struct {
+	BizNo  string `json:"biz_no"`  // 业务单号
+	TxHash string `json:"tx_hash"` // 链上交易哈希
+	Status string `json:"status"`  // 上链状态
+	// Extra 未声明的字段
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON 解析已知字段，其余字段保留在 Extra
+func (m *CommitReceipt) UnmarshalJSON(data []byte) error {
+	type plain CommitReceipt
+	return unmarshalWithExtra(data, (*plain)(m), &m.Extra)
+}
+
+// unmarshalWithExtra 将 data 解析到 v（须为结构体指针，且不带自定义 UnmarshalJSON），
+// 并把 v 未声明的字段写入 extra；没有未声明字段时 extra 为 nil。
+func unmarshalWithExtra(data []byte, v any, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		// data 为 null 等非对象时没有额外字段
+		*extra = nil
+		return nil
+	}
+	for _, name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(fields, name)
+	}
+	if len(fields) == 0 {
+		fields = nil
+	}
+	*extra = fields
+	return nil
+}
+
+// jsonFieldNames 返回结构体声明的 JSON 字段名
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
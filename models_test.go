@@ -0,0 +1,106 @@
+package junyousdk_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+func TestModelsKeepExtraFields(t *testing.T) {
+	data := []byte(`{"list":[{"open_id":"u1","balance":12.5,"frozen":"1"}],"total":1,"page":1,"page_size":10,"has_more":false,"cursor":"c1"}`)
+	var page junyousdk.EWTBalancePage
+	if err := json.Unmarshal(data, &page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.List) != 1 || page.List[0].Balance.String() != "12.5" || page.Total != 1 {
+		t.Fatalf("page = %+v", page)
+	}
+	if page.HasMore == nil || *page.HasMore {
+		t.Fatalf("HasMore = %v, want false", page.HasMore)
+	}
+	if string(page.Extra["cursor"]) != `"c1"` || len(page.Extra) != 1 {
+		t.Fatalf("Extra = %v", page.Extra)
+	}
+	if string(page.List[0].Extra["frozen"]) != `"1"` {
+		t.Fatalf("item Extra = %v", page.List[0].Extra)
+	}
+
+	var msg junyousdk.GOCRewardMessage
+	if err := json.Unmarshal([]byte(`{"from":"0xa","to":"0xb","amount":"1.50","biz_no":"GOC1"}`), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Amount.String() != "1.50" || msg.Extra != nil {
+		t.Fatalf("msg = %+v", msg)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"1,000"}`), &msg); err == nil {
+		t.Fatal("malformed amount accepted")
+	}
+}
+
+func TestModelsKeepUnassumedPayload(t *testing.T) {
+	var receipt junyousdk.CommitReceipt
+	if err := json.Unmarshal([]byte(`{"hash":"0xabc","state":1}`), &receipt); err != nil {
+		t.Fatal(err)
+	}
+	if receipt.TxHash != "" || receipt.Status != "" {
+		t.Fatalf("receipt = %+v, want assumed fields empty", receipt)
+	}
+	if string(receipt.Extra["hash"]) != `"0xabc"` || string(receipt.Extra["state"]) != "1" {
+		t.Fatalf("Extra = %v, want full payload", receipt.Extra)
+	}
+
+	var page junyousdk.EWTTransactionPage
+	if err := json.Unmarshal([]byte(`{"rows":[],"count":0}`), &page); err != nil {
+		t.Fatal(err)
+	}
+	if page.List != nil || len(page.Extra) != 2 {
+		t.Fatalf("page = %+v, want payload in Extra", page)
+	}
+}
+
+func TestTypedMethods(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	if err := srv.SetEWTBalance(openId, "8.25"); err != nil {
+		t.Fatal(err)
+	}
+
+	login, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: openId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err := client.API().GetEWTBalancePage(1, 10, login.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Data.List) != 1 || page.Data.List[0].OpenId != openId || page.Data.List[0].Balance.String() != "8.25" {
+		t.Fatalf("balance page = %+v", page.Data)
+	}
+
+	login, _ = client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: openId})
+	pre, err := client.API().PreRewardGOCMessage(junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("2")}, login.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pre.Data.BizNo == "" || pre.Data.Amount.String() != "2" || pre.Data.To != srv.UserAddress(openId) {
+		t.Fatalf("pre-reward message = %+v", pre.Data)
+	}
+
+	receipt, err := client.API().RewardGOCReceipt(junyousdk.CommitGOCRewardRequest{
+		BizNo: pre.Data.BizNo, Message: string(pre.RawData), PublicKey: "04ab", DerHex: "3006",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Data.BizNo != pre.Data.BizNo || receipt.Data.TxHash == "" || receipt.Data.Status != "success" {
+		t.Fatalf("receipt = %+v", receipt.Data)
+	}
+}
@@ -0,0 +1,70 @@
+package junyousdk
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/junyouava/junyou-sdk-go/internal"
+)
+
+// Clock 时钟，用于生成签名时间戳与验签；测试中可替换为固定时间
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc 函数形式的 Clock
+type ClockFunc func() time.Time
+
+// Now 实现 Clock
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock 系统时钟
+var SystemClock Clock = ClockFunc(time.Now)
+
+// NonceGenerator 签名 nonce 生成器
+type NonceGenerator interface {
+	Nonce() (string, error)
+}
+
+// NonceGeneratorFunc 函数形式的 NonceGenerator
+type NonceGeneratorFunc func() (string, error)
+
+// Nonce 实现 NonceGenerator
+func (f NonceGeneratorFunc) Nonce() (string, error) {
+	return f()
+}
+
+// uniqueNonceGenerator 默认 nonce 生成器：8 位随机前缀 + 8 位递增计数，共 16 位字母数字。
+// 同一生成器内保证唯一（计数 62^8 次内不回绕），不同进程间由随机前缀区分。
+type uniqueNonceGenerator struct {
+	prefix  string
+	counter atomic.Uint64
+}
+
+// NewNonceGenerator 创建默认 nonce 生成器
+func NewNonceGenerator() (NonceGenerator, error) {
+	prefix, err := internal.GenerateNonce(8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce prefix: %w", err)
+	}
+
+	// 计数起点随机，避免进程重启后与前一进程的 nonce 序列重叠
+	var seed [8]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return nil, fmt.Errorf("failed to seed nonce counter: %w", err)
+	}
+
+	g := &uniqueNonceGenerator{prefix: prefix}
+	g.counter.Store(binary.BigEndian.Uint64(seed[:]))
+	return g, nil
+}
+
+// Nonce 实现 NonceGenerator
+func (g *uniqueNonceGenerator) Nonce() (string, error) {
+	return g.prefix + internal.FormatFixed(g.counter.Add(1), 8), nil
+}
@@ -0,0 +1,145 @@
+package junyousdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrStopIteration 遍历回调返回该错误时提前结束遍历，遍历方法返回 nil
+var ErrStopIteration = errors.New("junyousdk: stop iteration")
+
+// ErrPaginationLoop 逐页遍历无法结束：某页与上一页内容完全相同（服务端可能忽略了 page 参数），或页数超过 MaxPages
+var ErrPaginationLoop = errors.New("junyousdk: pagination does not terminate")
+
+// ErrUnrecognizedPage 分页响应中没有 list 字段而有其他字段：实际结构与 EWTBalancePage 等模型假设的字段不符，
+// 原样内容见对应模型的 Extra
+var ErrUnrecognizedPage = errors.New("junyousdk: unrecognized page response")
+
+// MaxPages 逐页遍历最多查询的页数，防止服务端分页字段异常时无限循环
+const MaxPages = 10000
+
+// EWTTransactionFilter 权证交易明细筛选条件；空字符串与 0 表示不筛选
+type EWTTransactionFilter struct {
+	// TransactionType 交易类型
+	TransactionType string
+	// BizType 业务类型
+	BizType string
+	// Year 年份
+	Year int
+	// Month 月份，1-12
+	Month int
+}
+
+// EachEWTBalance 从第 1 页开始逐页查询权证余额，对每条记录调用 fn；
+// pageSize 非正时取 DefaultPageSize，openAuth 语义同 GetEWTBalance。
+// 服务端返回 has_more 时以其为准，否则以 total 判断，二者都没有时遇到不满一页即结束。
+// fn 返回 ErrStopIteration 时提前结束并返回 nil，返回其他错误时结束并原样返回；ctx 取消时返回 ctx.Err()。
+// 某页与上一页内容相同或页数超过 MaxPages 时返回 ErrPaginationLoop，重复的页不会交给 fn。
+func (s *APIService) EachEWTBalance(ctx context.Context, pageSize int, openAuth string, fn func(EWTBalance) error) error {
+	return eachPage(ctx, pageSize, func(page, pageSize int) ([]EWTBalance, int, *bool, error) {
+		result, err := s.GetEWTBalancePageContext(ctx, page, pageSize, openAuth)
+		if err = flowResultError(result, err); err != nil {
+			return nil, 0, nil, err
+		}
+		if err := checkPageShape(result.Data.List, result.Data.Extra); err != nil {
+			return nil, 0, nil, err
+		}
+		return result.Data.List, result.Data.Total, result.Data.HasMore, nil
+	}, fn)
+}
+
+// EachEWTTransaction 按 filter 逐页查询权证交易明细，对每条记录调用 fn；
+// pageSize、openAuth、结束条件与 fn 的返回值同 EachEWTBalance。
+func (s *APIService) EachEWTTransaction(ctx context.Context, filter EWTTransactionFilter, pageSize int, openAuth string, fn func(EWTTransaction) error) error {
+	return s.eachEWTTransaction(ctx, pageSize, func(page, pageSize int) (*Result[EWTTransactionPage], error) {
+		return s.GetEWTTransactionPageContext(ctx,
+			page, pageSize,
+			filter.TransactionType, filter.BizType,
+			filter.Year, filter.Month,
+			openAuth,
+		)
+	}, fn)
+}
+
+// eachEWTTransaction 用 fetch 逐页查询权证交易明细并对每条记录调用 fn，fetch 决定 X-Open-Auth 的来源
+func (s *APIService) eachEWTTransaction(ctx context.Context, pageSize int, fetch func(page, pageSize int) (*Result[EWTTransactionPage], error), fn func(EWTTransaction) error) error {
+	return eachPage(ctx, pageSize, func(page, pageSize int) ([]EWTTransaction, int, *bool, error) {
+		result, err := fetch(page, pageSize)
+		if err = flowResultError(result, err); err != nil {
+			return nil, 0, nil, err
+		}
+		if err := checkPageShape(result.Data.List, result.Data.Extra); err != nil {
+			return nil, 0, nil, err
+		}
+		return result.Data.List, result.Data.Total, result.Data.HasMore, nil
+	}, fn)
+}
+
+// checkPageShape 检查分页响应是否符合模型假设的结构，避免字段名不符时被当作空页而静默结束遍历
+func checkPageShape[T any](list []T, extra map[string]json.RawMessage) error {
+	if list != nil || len(extra) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return fmt.Errorf("%w: no list field, got %s", ErrUnrecognizedPage, strings.Join(keys, ", "))
+}
+
+// eachPage 逐页调用 fetch 并对每条记录调用 fn，直到 has_more 为 false、已取满 total、不满一页或空页；
+// 服务端总是返回同一页或分页字段始终表示还有更多时，以 ErrPaginationLoop 结束
+func eachPage[T any](ctx context.Context, pageSize int, fetch func(page, pageSize int) (items []T, total int, hasMore *bool, err error), fn func(T) error) error {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	seen := 0
+	var previous []T
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if page > MaxPages {
+			return fmt.Errorf("%w: more than %d pages", ErrPaginationLoop, MaxPages)
+		}
+		items, total, hasMore, err := fetch(page, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(items) > 0 && reflect.DeepEqual(items, previous) {
+			return fmt.Errorf("%w: page %d repeats page %d", ErrPaginationLoop, page, page-1)
+		}
+		previous = items
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+		seen += len(items)
+
+		switch {
+		case len(items) == 0:
+			return nil
+		case hasMore != nil:
+			if !*hasMore {
+				return nil
+			}
+		case total > 0:
+			if seen >= total {
+				return nil
+			}
+		case len(items) < pageSize:
+			return nil
+		}
+	}
+}
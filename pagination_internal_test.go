@@ -0,0 +1,124 @@
+package junyousdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// pagesOf 返回按 pageSize 切分 items 的 fetch，附带 total 与 has_more
+func pagesOf(items []int, total int, withHasMore bool, calls *int) func(page, pageSize int) ([]int, int, *bool, error) {
+	return func(page, pageSize int) ([]int, int, *bool, error) {
+		*calls++
+		start := min((page-1)*pageSize, len(items))
+		end := min(start+pageSize, len(items))
+		var hasMore *bool
+		if withHasMore {
+			more := end < len(items)
+			hasMore = &more
+		}
+		return items[start:end], total, hasMore, nil
+	}
+}
+
+func TestEachPageStops(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		name      string
+		items     []int
+		total     int
+		hasMore   bool
+		wantCalls int
+	}{
+		{"has_more", items, 0, true, 4},
+		{"total", items, len(items), false, 4},
+		{"short page", items, 0, false, 4},
+		{"empty page after full pages", items[:9], 0, false, 4},
+		{"empty", nil, 0, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			var got []int
+			err := eachPage(context.Background(), 3, pagesOf(tt.items, tt.total, tt.hasMore, &calls), func(v int) error {
+				got = append(got, v)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.items) || calls != tt.wantCalls {
+				t.Fatalf("got %v in %d calls, want %d items in %d calls", got, calls, len(tt.items), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestEachPageRepeatedPage(t *testing.T) {
+	calls := 0
+	more := true
+	// 服务端忽略 page 参数，总是返回第 1 页并表示还有更多
+	fetch := func(page, pageSize int) ([]int, int, *bool, error) {
+		calls++
+		return []int{1, 2, 3}, 100, &more, nil
+	}
+	var got []int
+	err := eachPage(context.Background(), 3, fetch, func(v int) error {
+		got = append(got, v)
+		return nil
+	})
+	if !errors.Is(err, ErrPaginationLoop) {
+		t.Fatalf("err = %v, want ErrPaginationLoop", err)
+	}
+	if calls != 2 || len(got) != 3 {
+		t.Fatalf("got %v in %d calls, want first page only in 2 calls", got, calls)
+	}
+}
+
+func TestEachPageMaxPages(t *testing.T) {
+	calls := 0
+	more := true
+	fetch := func(page, pageSize int) ([]int, int, *bool, error) {
+		calls++
+		return []int{page}, 0, &more, nil
+	}
+	err := eachPage(context.Background(), 1, fetch, func(int) error { return nil })
+	if !errors.Is(err, ErrPaginationLoop) {
+		t.Fatalf("err = %v, want ErrPaginationLoop", err)
+	}
+	if calls != MaxPages {
+		t.Fatalf("calls = %d, want %d", calls, MaxPages)
+	}
+}
+
+func TestEachPageCallbackErrors(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	calls := 0
+	var got []int
+	err := eachPage(context.Background(), 3, pagesOf(items, 0, false, &calls), func(v int) error {
+		got = append(got, v)
+		if v == 4 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil || len(got) != 4 || calls != 2 {
+		t.Fatalf("stop: got %v in %d calls, err %v", got, calls, err)
+	}
+
+	fnErr := errors.New("boom")
+	calls = 0
+	if err := eachPage(context.Background(), 3, pagesOf(items, 0, false, &calls), func(int) error { return fnErr }); err != fnErr {
+		t.Fatalf("err = %v, want %v", err, fnErr)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = eachPage(ctx, 3, pagesOf(items, 0, false, &calls), func(int) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Fatalf("canceled: err %v after %d calls, want context.Canceled after 1", err, calls)
+	}
+}
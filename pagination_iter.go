@@ -0,0 +1,46 @@
+//go:build go1.23
+
+package junyousdk
+
+import (
+	"context"
+	"iter"
+)
+
+// EWTBalances 返回逐页遍历权证余额的迭代器，参数与结束条件同 EachEWTBalance；
+// 查询失败或 ctx 取消时产出一次零值与错误后结束，range 中 break 会停止后续查询。
+//
+//	for balance, err := range client.API().EWTBalances(ctx, 50, "") {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(balance.OpenId, balance.Balance)
+//	}
+func (s *APIService) EWTBalances(ctx context.Context, pageSize int, openAuth string) iter.Seq2[EWTBalance, error] {
+	return func(yield func(EWTBalance, error) bool) {
+		err := s.EachEWTBalance(ctx, pageSize, openAuth, yieldItem(yield))
+		if err != nil {
+			yield(EWTBalance{}, err)
+		}
+	}
+}
+
+// EWTTransactions 返回按 filter 逐页遍历权证交易明细的迭代器，参数与结束条件同 EachEWTTransaction，错误处理同 EWTBalances
+func (s *APIService) EWTTransactions(ctx context.Context, filter EWTTransactionFilter, pageSize int, openAuth string) iter.Seq2[EWTTransaction, error] {
+	return func(yield func(EWTTransaction, error) bool) {
+		err := s.EachEWTTransaction(ctx, filter, pageSize, openAuth, yieldItem(yield))
+		if err != nil {
+			yield(EWTTransaction{}, err)
+		}
+	}
+}
+
+// yieldItem 将 yield 转为遍历回调；yield 返回 false 时以 ErrStopIteration 结束遍历
+func yieldItem[T any](yield func(T, error) bool) func(T) error {
+	return func(item T) error {
+		if !yield(item, nil) {
+			return ErrStopIteration
+		}
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+//go:build go1.23
+
+package junyousdk_test
+
+import (
+	"context"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+func TestEWTTransactionsIteratorBreak(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	seedTransactions(t, srv, srv.AddUser("13800138000"), 25)
+
+	n := 0
+	for _, err := range client.API().EWTTransactions(context.Background(), junyousdk.EWTTransactionFilter{}, 7, "") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Fatalf("iterated %d items, want 3", n)
+	}
+	// break 后不再请求下一页
+	if got := pageRequests(srv); got != 1 {
+		t.Fatalf("requests = %d, want 1", got)
+	}
+}
+
+func TestEWTBalancesIteratorError(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var errs []error
+	for _, err := range client.API().EWTBalances(ctx, 10, "") {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || errs[0] != context.Canceled {
+		t.Fatalf("errs = %v, want one context.Canceled", errs)
+	}
+}
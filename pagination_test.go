@@ -0,0 +1,82 @@
+package junyousdk_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// seedTransactions 为 openId 添加 n 条 2026 年 3 月的流水，biz_no 为 B0..B(n-1)
+func seedTransactions(t *testing.T, srv *junyoutest.Server, openId string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := srv.AddTransaction(junyoutest.Transaction{
+			BizNo:           fmt.Sprint("B", i),
+			OpenId:          openId,
+			TransactionType: "in",
+			BizType:         junyoutest.BizTypeEWTReleaseByPartner,
+			Amount:          "1",
+			CreatedAt:       time.Date(2026, 3, 1, i, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// pageRequests 返回交易明细请求数
+func pageRequests(srv *junyoutest.Server) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if strings.HasPrefix(r.Path, junyousdk.APIPathEWTTransactionDetails) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestEachEWTTransaction(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	seedTransactions(t, srv, srv.AddUser("13800138000"), 25)
+
+	seen := map[string]bool{}
+	err = client.API().EachEWTTransaction(context.Background(), junyousdk.EWTTransactionFilter{Year: 2026, Month: 3}, 10, "", func(tx junyousdk.EWTTransaction) error {
+		seen[tx.BizNo] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 25 {
+		t.Fatalf("saw %d distinct transactions, want 25", len(seen))
+	}
+	if n := pageRequests(srv); n != 3 {
+		t.Fatalf("requests = %d, want 3", n)
+	}
+}
+
+func TestEachRejectsUnrecognizedPage(t *testing.T) {
+	client := rawDataServer(t, `{"records":[{"open_id":"u1","balance":"1"}],"count":1}`)
+
+	calls := 0
+	err := client.API().EachEWTBalance(context.Background(), 10, "", func(junyousdk.EWTBalance) error {
+		calls++
+		return nil
+	})
+	if !errors.Is(err, junyousdk.ErrUnrecognizedPage) || !strings.Contains(err.Error(), "count, records") {
+		t.Fatalf("err = %v, want ErrUnrecognizedPage listing the returned fields", err)
+	}
+	if calls != 0 {
+		t.Fatalf("fn called %d times, want 0", calls)
+	}
+}
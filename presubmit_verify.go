@@ -0,0 +1,236 @@
+package junyousdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrPreSubmitMismatch 预提交返回的消息与请求或配置不一致，不应签名
+var ErrPreSubmitMismatch = errors.New("junyousdk: pre-submit message mismatch")
+
+// ErrPreSubmitExpectationMissing 校验预提交消息所需的期望值为空（Config.EnterpriseAddress、biz_type 或收款地址），消息无法校验，不应签名
+var ErrPreSubmitExpectationMissing = errors.New("junyousdk: pre-submit expectation not configured")
+
+// PreSubmitMismatchError 预提交消息校验错误，列出全部不一致的字段；errors.Is(err, ErrPreSubmitMismatch) 为 true
+type PreSubmitMismatchError struct {
+	// BizNo 消息中的业务单号
+	BizNo string
+	// Fields 不一致的字段，Message 中含期望值与实际值
+	Fields []FieldError
+}
+
+// Error 实现 error 接口
+func (e *PreSubmitMismatchError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return fmt.Sprintf("pre-submit message mismatch (biz_no %s): %s", e.BizNo, strings.Join(msgs, "; "))
+}
+
+// Unwrap 返回 ErrPreSubmitMismatch；有字段缺少期望值时还返回 ErrPreSubmitExpectationMissing
+func (e *PreSubmitMismatchError) Unwrap() []error {
+	errs := []error{ErrPreSubmitMismatch}
+	for _, f := range e.Fields {
+		if f.Err != nil && !slices.Contains(errs, f.Err) {
+			errs = append(errs, f.Err)
+		}
+	}
+	return errs
+}
+
+// messageChecker 收集预提交消息与期望值不一致的字段
+type messageChecker struct {
+	fields []FieldError
+}
+
+func (c *messageChecker) mismatch(field, expected, actual string) {
+	c.fields = append(c.fields, FieldError{
+		Field:   field,
+		Message: fmt.Sprintf("expected %q, got %q", expected, actual),
+		Err:     ErrPreSubmitMismatch,
+	})
+}
+
+// missing 记录缺少期望值的字段
+func (c *messageChecker) missing(field, source, actual string) {
+	c.fields = append(c.fields, FieldError{
+		Field:   field,
+		Message: fmt.Sprintf("no expected value (%s is empty), got %q", source, actual),
+		Err:     ErrPreSubmitExpectationMissing,
+	})
+}
+
+// equal 比较字符串；expected 为空时记为缺少期望值，source 为期望值的来源
+func (c *messageChecker) equal(field, source, expected, actual string) {
+	switch {
+	case expected == "":
+		c.missing(field, source, actual)
+	case expected != actual:
+		c.mismatch(field, expected, actual)
+	}
+}
+
+// address 比较链上地址，忽略十六进制大小写；expected 为空时记为缺少期望值
+func (c *messageChecker) address(field, source, expected, actual string) {
+	switch {
+	case expected == "":
+		c.missing(field, source, actual)
+	case !strings.EqualFold(expected, actual):
+		c.mismatch(field, expected, actual)
+	}
+}
+
+// amount 按数值比较金额（"100" 与 "100.00" 相等）
+func (c *messageChecker) amount(field string, expected, actual Amount) {
+	if !actual.IsSet() || expected.Cmp(actual) != 0 {
+		c.mismatch(field, expected.String(), actual.String())
+	}
+}
+
+// ratio 按数值比较比例，未设置视为 0
+func (c *messageChecker) ratio(field string, expected, actual Ratio) {
+	if expected.Cmp(actual) != 0 {
+		c.mismatch(field, expected.String(), actual.String())
+	}
+}
+
+func (c *messageChecker) err(bizNo string) error {
+	if len(c.fields) == 0 {
+		return nil
+	}
+	return &PreSubmitMismatchError{BizNo: bizNo, Fields: c.fields}
+}
+
+// decodePreSubmitMessage 解析预提交 data；data 可为 JSON 对象，或内容为 JSON 对象的 JSON 字符串
+func decodePreSubmitMessage(data []byte, v any) error {
+	var message string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &message); err != nil {
+			return err
+		}
+		data = []byte(message)
+	}
+	if len(data) == 0 || isNullData(data) {
+		return ErrEmptyPreSubmitMessage
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: cannot parse message: %v", ErrPreSubmitMismatch, err)
+	}
+	return nil
+}
+
+// VerifyPreReward 在签名前校验 PreRewardGOC 返回的消息：data 为预提交 data 原始字节（Result.RawData）或 PreSubmitMessage 的结果。
+// 校验 amount 与 req 数值相等、biz_no 非空，biz_type 与 Config.GOCRewardBizType、from 与 Config.EnterpriseAddress、
+// to 与 expectedRecipient 一致。不一致时返回 *PreSubmitMismatchError，此时不应签名提交；
+// 上述期望值为空时同样返回该错误，errors.Is(err, ErrPreSubmitExpectationMissing) 为 true。
+func (s *APIService) VerifyPreReward(req PreGOCRewardRequest, expectedRecipient string, data []byte) (*GOCRewardMessage, error) {
+	var msg GOCRewardMessage
+	if err := decodePreSubmitMessage(data, &msg); err != nil {
+		return nil, err
+	}
+	config := s.client.config
+	var c messageChecker
+	if msg.BizNo == "" {
+		c.mismatch("biz_no", "non-empty", "")
+	}
+	c.amount("amount", req.Amount, msg.Amount)
+	c.equal("biz_type", "Config.GOCRewardBizType", config.GOCRewardBizType, msg.BizType)
+	c.address("from", "Config.EnterpriseAddress", config.EnterpriseAddress, msg.From)
+	c.address("to", "expectedRecipient", expectedRecipient, msg.To)
+	return &msg, c.err(msg.BizNo)
+}
+
+// VerifyPreEWTRelease 在签名前校验 PreCommitEWTReleaseByPartner 返回的消息，data 同 VerifyPreReward。
+// 接口文档只给出 GOC 预提交 data 的字段，未给出权证释放预提交 data 的结构；这里假设其字段名与预提交请求及 GOC 消息相同，
+// 因此除 biz_no（提交时须原样回传）必须非空外，其余字段只在消息中出现时才校验：
+// amount、ratio、各级合伙人 OpenId 与比例与 req 一致，biz_type 与 Config.EWTReleaseBizType、from 与 Config.EnterpriseAddress、
+// to 与 expectedRecipient 一致。不一致或所需期望值为空时返回 *PreSubmitMismatchError。
+func (s *APIService) VerifyPreEWTRelease(req PreEWTReleaseByPartnerRequest, expectedRecipient string, data []byte) (*EWTReleaseMessage, error) {
+	var msg EWTReleaseMessage
+	if err := decodePreSubmitMessage(data, &msg); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := decodePreSubmitMessage(data, &fields); err != nil {
+		return nil, err
+	}
+	has := func(field string) bool {
+		_, ok := fields[field]
+		return ok
+	}
+
+	config := s.client.config
+	var c messageChecker
+	if msg.BizNo == "" {
+		c.mismatch("biz_no", "non-empty", "")
+	}
+	if has("amount") {
+		c.amount("amount", req.Amount, msg.Amount)
+	}
+	if has("ratio") {
+		c.ratio("ratio", req.Ratio, msg.Ratio)
+	}
+	if has("level1_open_id") && req.Level1OpenId != msg.Level1OpenId {
+		c.mismatch("level1_open_id", req.Level1OpenId, msg.Level1OpenId)
+	}
+	if has("level1_ratio") {
+		c.ratio("level1_ratio", req.Level1Ratio, msg.Level1Ratio)
+	}
+	if has("level2_open_id") && req.Level2OpenId != msg.Level2OpenId {
+		c.mismatch("level2_open_id", req.Level2OpenId, msg.Level2OpenId)
+	}
+	if has("level2_ratio") {
+		c.ratio("level2_ratio", req.Level2Ratio, msg.Level2Ratio)
+	}
+	if has("biz_type") {
+		c.equal("biz_type", "Config.EWTReleaseBizType", config.EWTReleaseBizType, msg.BizType)
+	}
+	if has("from") {
+		c.address("from", "Config.EnterpriseAddress", config.EnterpriseAddress, msg.From)
+	}
+	if has("to") {
+		c.address("to", "expectedRecipient", expectedRecipient, msg.To)
+	}
+	return &msg, c.err(msg.BizNo)
+}
+
+// preSubmitRecipient 未开启 Config.VerifyPreSubmit 时返回空字符串；开启时检查校验所需的配置
+// （Config.EnterpriseAddress、bizTypeField 对应的 biz_type 与 Config.AddressResolver），缺少时返回 ErrPreSubmitExpectationMissing，
+// 流程不发出任何请求；配置齐全时返回 openId 的链上地址
+func (s *APIService) preSubmitRecipient(ctx context.Context, bizTypeField, bizType, openId string) (string, error) {
+	config := s.client.config
+	if !config.VerifyPreSubmit {
+		return "", nil
+	}
+	var missing []string
+	if config.EnterpriseAddress == "" {
+		missing = append(missing, "Config.EnterpriseAddress")
+	}
+	if bizType == "" {
+		missing = append(missing, bizTypeField)
+	}
+	if config.AddressResolver == nil {
+		missing = append(missing, "Config.AddressResolver")
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%w: %s required when Config.VerifyPreSubmit is set", ErrPreSubmitExpectationMissing, strings.Join(missing, ", "))
+	}
+	return s.recipientAddress(ctx, openId)
+}
+
+// recipientAddress 通过 Config.AddressResolver 查询 openId 的链上地址；查得空地址时返回 ErrPreSubmitExpectationMissing
+func (s *APIService) recipientAddress(ctx context.Context, openId string) (string, error) {
+	address, err := s.client.config.AddressResolver(ctx, openId)
+	if err != nil {
+		return "", fmt.Errorf("resolve chain address of %s: %w", openId, err)
+	}
+	if address == "" {
+		return "", fmt.Errorf("%w: no chain address for %s", ErrPreSubmitExpectationMissing, openId)
+	}
+	return address, nil
+}
@@ -0,0 +1,105 @@
+package junyousdk_test
+
+import (
+	"errors"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+)
+
+const (
+	testEnterpriseAddress = "0x1111111111111111111111111111111111111111"
+	testRecipientAddress  = "0x2222222222222222222222222222222222222222"
+)
+
+// gocMessage 构造 GOC 预提交消息
+func gocMessage(from, to, bizType string) []byte {
+	return []byte(`{"from":"` + from + `","to":"` + to + `","amount":"100.00","biz_no":"B1","biz_type":"` + bizType + `"}`)
+}
+
+// mismatchFields 返回 *PreSubmitMismatchError 中的字段名
+func mismatchFields(t *testing.T, err error) []string {
+	t.Helper()
+	var mismatch *junyousdk.PreSubmitMismatchError
+	if !errors.As(err, &mismatch) || !errors.Is(err, junyousdk.ErrPreSubmitMismatch) {
+		t.Fatalf("err = %v, want *PreSubmitMismatchError", err)
+	}
+	var fields []string
+	for _, f := range mismatch.Fields {
+		fields = append(fields, f.Field)
+	}
+	return fields
+}
+
+func TestVerifyPreReward(t *testing.T) {
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId(testAccessId).WithAccessKey(testAccessKey).
+		WithEnterpriseAddress(testEnterpriseAddress).
+		WithBizTypes("GOC", "EWT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("100")}
+
+	// 数值相等的金额、大小写不同的地址视为一致
+	msg, err := client.API().VerifyPreReward(req, "0X2222222222222222222222222222222222222222", gocMessage(testEnterpriseAddress, testRecipientAddress, "GOC"))
+	if err != nil || msg.BizNo != "B1" {
+		t.Fatalf("VerifyPreReward = %+v, %v", msg, err)
+	}
+
+	_, err = client.API().VerifyPreReward(req, testRecipientAddress, gocMessage(testRecipientAddress, testEnterpriseAddress, "EWT"))
+	if fields := mismatchFields(t, err); len(fields) != 3 || errors.Is(err, junyousdk.ErrPreSubmitExpectationMissing) {
+		t.Fatalf("fields = %v, err = %v; want biz_type, from, to mismatch", fields, err)
+	}
+
+	// 期望的收款地址为空时不能通过
+	_, err = client.API().VerifyPreReward(req, "", gocMessage(testEnterpriseAddress, testRecipientAddress, "GOC"))
+	if fields := mismatchFields(t, err); len(fields) != 1 || fields[0] != "to" || !errors.Is(err, junyousdk.ErrPreSubmitExpectationMissing) {
+		t.Fatalf("fields = %v, err = %v; want missing to", fields, err)
+	}
+}
+
+func TestVerifyPreRewardRequiresConfig(t *testing.T) {
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().WithAccessId(testAccessId).WithAccessKey(testAccessKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("100")}
+	_, err = client.API().VerifyPreReward(req, testRecipientAddress, gocMessage(testEnterpriseAddress, testRecipientAddress, "GOC"))
+	if fields := mismatchFields(t, err); len(fields) != 2 || fields[0] != "biz_type" || fields[1] != "from" ||
+		!errors.Is(err, junyousdk.ErrPreSubmitExpectationMissing) {
+		t.Fatalf("fields = %v, err = %v; want missing biz_type and from", fields, err)
+	}
+}
+
+func TestVerifyPreEWTReleaseChecksPresentFields(t *testing.T) {
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId(testAccessId).WithAccessKey(testAccessKey).
+		WithEnterpriseAddress(testEnterpriseAddress).
+		WithBizTypes("GOC", "EWT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount: junyousdk.MustParseAmount("100"), Ratio: junyousdk.MustParseRatio("0.5"),
+		Level1OpenId: "level1-user", Level1Ratio: junyousdk.MustParseRatio("0.1"),
+	}
+
+	// 消息中没有的字段不校验，期望的收款地址为空也不影响
+	msg, err := client.API().VerifyPreEWTRelease(req, "", []byte(`{"biz_no":"E1","amount":"100.0"}`))
+	if err != nil || msg.BizNo != "E1" {
+		t.Fatalf("VerifyPreEWTRelease = %+v, %v; want only present fields checked", msg, err)
+	}
+
+	message := []byte(`{"biz_no":"E1","amount":"100","ratio":"0.4","level1_open_id":"other","biz_type":"EWT","from":"` + testEnterpriseAddress + `","to":"` + testRecipientAddress + `"}`)
+	_, err = client.API().VerifyPreEWTRelease(req, testRecipientAddress, message)
+	if fields := mismatchFields(t, err); len(fields) != 2 || fields[0] != "ratio" || fields[1] != "level1_open_id" {
+		t.Fatalf("fields = %v; want ratio and level1_open_id", fields)
+	}
+
+	// biz_no 提交时须原样回传，缺少时总是失败
+	_, err = client.API().VerifyPreEWTRelease(req, testRecipientAddress, []byte(`{"amount":"100"}`))
+	if fields := mismatchFields(t, err); len(fields) != 1 || fields[0] != "biz_no" {
+		t.Fatalf("fields = %v; want biz_no", fields)
+	}
+}
@@ -0,0 +1,115 @@
+package junyousdk_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// reloginRecorder 记录 OnRelogin 事件
+type reloginRecorder struct {
+	mu     sync.Mutex
+	events []junyousdk.ReloginEvent
+}
+
+func (r *reloginRecorder) hook(_ context.Context, event junyousdk.ReloginEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *reloginRecorder) Events() []junyousdk.ReloginEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]junyousdk.ReloginEvent(nil), r.events...)
+}
+
+func TestAutoRelogin(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	recorder := &reloginRecorder{}
+	client, err := junyousdk.NewClient(srv.Config().WithAutoRelogin(true).WithOnRelogin(recorder.hook))
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	ctx := context.Background()
+
+	if _, err := client.API().GetEWTBalancePageByOpenIdContext(ctx, 1, 10, openId); err != nil {
+		t.Fatal(err)
+	}
+	srv.RevokeTokens(openId)
+
+	result, err := client.API().GetEWTBalancePageByOpenIdContext(ctx, 1, 10, openId)
+	if err != nil || !result.Success {
+		t.Fatalf("result = %+v, err = %v; want success after relogin", result, err)
+	}
+	events := recorder.Events()
+	if len(events) != 1 || events[0].OpenId != openId || events[0].Policy != junyousdk.TokenCached ||
+		!errors.Is(events[0].Cause, junyousdk.ErrMissingUserIdentity) || events[0].Err != nil {
+		t.Fatalf("events = %+v, want one successful relogin", events)
+	}
+	if n := loginCount(srv); n != 2 {
+		t.Fatalf("logins = %d, want 2", n)
+	}
+}
+
+func TestAutoReloginDisabled(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	ctx := context.Background()
+
+	if _, err := client.Tokens().Token(ctx, openId); err != nil {
+		t.Fatal(err)
+	}
+	srv.RevokeTokens(openId)
+	if _, err := client.API().GetEWTBalancePageByOpenIdContext(ctx, 1, 10, openId); !errors.Is(err, junyousdk.ErrMissingUserIdentity) {
+		t.Fatalf("err = %v, want ErrMissingUserIdentity", err)
+	}
+	if n := loginCount(srv); n != 1 {
+		t.Fatalf("logins = %d, want 1", n)
+	}
+}
+
+func TestQueryEWTTransactionsWithOpenIdRelogins(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	recorder := &reloginRecorder{}
+	client, err := junyousdk.NewClient(srv.Config().WithAutoRelogin(true).WithOnRelogin(recorder.hook))
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	if err := srv.AddTransaction(junyoutest.Transaction{
+		BizNo: "EWT1", OpenId: openId, TransactionType: "in", BizType: junyoutest.BizTypeEWTReleaseByPartner, Amount: "1",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// 缓存的 Token 失效后，查询应与 ...ByOpenId 方法一样重新登录
+	if _, err := client.Tokens().Token(ctx, openId); err != nil {
+		t.Fatal(err)
+	}
+	srv.RevokeTokens(openId)
+
+	items, err := client.API().QueryEWTTransactions(ctx, junyousdk.NewTransactionQuery().WithOpenId(openId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].BizNo != "EWT1" {
+		t.Fatalf("items = %+v, want EWT1", items)
+	}
+	if events := recorder.Events(); len(events) != 1 {
+		t.Fatalf("events = %+v, want one relogin", events)
+	}
+}
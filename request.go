@@ -2,13 +2,13 @@ package junyousdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"reflect"
 )
 
 // wrappedResponse API 响应结构（带 result 包装）
@@ -17,27 +17,39 @@ type wrappedResponse[T any] struct {
 }
 
 // parseErrorResponse 解析错误响应
-func parseErrorResponse[T any](data []byte, statusCode int, apiPath string) (*Result[T], error) {
-	// 尝试解析响应
-	apiResponse, err := parseResponse[T](data)
-	if err == nil {
-		return buildErrorResult(apiResponse, statusCode, apiPath, "http")
+func parseErrorResponse[T any](resp *Response, apiPath string) (*Result[T], error) {
+	if resp.Result != nil {
+		return buildErrorResult[T](resp.Result, resp.StatusCode, apiPath)
 	}
 
 	// 无法解析 JSON，返回原始响应
-	message := string(data)
+	message := string(resp.RawBody)
 	if message == "" {
-		message = fmt.Sprintf("HTTP %d", statusCode)
+		message = fmt.Sprintf("HTTP %d", resp.StatusCode)
 	}
 	result := NewSysErrorResult[T](message)
-	result.Code = statusCode
-	return result, fmt.Errorf("http status %d on %s: %s", statusCode, apiPath, message)
+	result.Code = resp.StatusCode
+	return result, &APIError{
+		StatusCode: resp.StatusCode,
+		APIPath:    apiPath,
+		Message:    message,
+	}
 }
 
-// isZeroValue 检查值是否为零值
-func isZeroValue[T any](v T) bool {
-	var zero T
-	return reflect.DeepEqual(v, zero)
+// isNullData 检查原始 data 是否为空或 null
+func isNullData(data json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+// decodeData 将原始 data 解码为 T；空或 null 时返回零值
+func decodeData[T any](data json.RawMessage) (T, error) {
+	var v T
+	if isNullData(data) {
+		return v, nil
+	}
+	err := json.Unmarshal(data, &v)
+	return v, err
 }
 
 // parseResponse 解析响应（支持带 result 包装和不带包装两种格式）
@@ -56,106 +68,165 @@ func parseResponse[T any](data []byte) (*Result[T], error) {
 	return &directResp, nil
 }
 
-// buildErrorResult 构建错误结果和错误消息
-func buildErrorResult[T any](apiResponse *Result[T], statusCode int, apiPath string, errorType string) (*Result[T], error) {
-	result := NewParamErrorResult[T](apiResponse.Message)
-	if errorType == "http" {
+// buildErrorResult 构建错误结果和 *APIError。
+// statusCode 为 HTTP 状态码；HTTP 成功但业务 code 非 200 时，Result.Code 取业务 code。
+func buildErrorResult[T any](apiResponse *Result[json.RawMessage], statusCode int, apiPath string) (*Result[T], error) {
+	var result *Result[T]
+	if statusCode != http.StatusOK {
 		result = NewSysErrorResult[T](apiResponse.Message)
+		result.Code = statusCode
+	} else {
+		result = NewParamErrorResult[T](apiResponse.Message)
+		result.Code = apiResponse.Code
 	}
-	result.Code = statusCode
 	result.ErrCode = apiResponse.ErrCode
-	result.Data = apiResponse.Data
+	// 错误响应的 data 尽力解码，失败时保持零值，原始内容见 APIError.Data
+	result.Data, _ = decodeData[T](apiResponse.Data)
 
-	// 构建错误消息
-	errorMsg := fmt.Sprintf("%s error %d on %s: %s", errorType, statusCode, apiPath, apiResponse.Message)
-	if !isZeroValue(apiResponse.Data) {
-		dataBytes, _ := json.Marshal(apiResponse.Data)
-		errorMsg = fmt.Sprintf("%s (data: %s)", errorMsg, string(dataBytes))
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Code:       apiResponse.Code,
+		ErrCode:    apiResponse.ErrCode,
+		APIPath:    apiPath,
+		Message:    apiResponse.Message,
+	}
+	if !isNullData(apiResponse.Data) {
+		apiErr.Data = apiResponse.Data
 	}
 
-	return result, errors.New(errorMsg)
+	return result, apiErr
 }
 
 // DoRequest 执行请求。extraHeaders 可选，用于追加请求头（如 X-Open-Auth）。
 func DoRequest[T any](c *Client, method, apiPath string, body any, extraHeaders map[string]string) (*Result[T], error) {
-	// 生成认证 Header
-	header, err := c.auth.GenerateAuthHeader(method, apiPath)
+	return DoRequestContext[T](context.Background(), c, method, apiPath, body, extraHeaders)
+}
+
+// DoRequestContext 同 DoRequest，ctx 会传递到底层 HTTP 请求，用于取消与超时控制。
+// 请求经过 Client 的拦截器链（重试 → 签名 → Use 添加的拦截器）后发送。
+func DoRequestContext[T any](ctx context.Context, c *Client, method, apiPath string, body any, extraHeaders map[string]string) (*Result[T], error) {
+	header := make(http.Header)
+	for k, v := range extraHeaders {
+		if v != "" {
+			header.Set(k, v)
+		}
+	}
+
+	resp, err := c.handler()(ctx, &Request{
+		Method:  method,
+		APIPath: apiPath,
+		Body:    body,
+		Header:  header,
+	})
+	if err != nil {
+		var reqErr *requestError
+		if errors.As(err, &reqErr) {
+			return NewSysErrorResult[T](reqErr.message), reqErr.err
+		}
+		return NewSysErrorResult[T]("request failed"), fmt.Errorf("HTTP request failed: %w", err)
+	}
+	if resp == nil {
+		return NewSysErrorResult[T]("interceptor chain returned a nil response"), errors.New("interceptor chain returned a nil response")
+	}
+
+	// 检查 HTTP 状态码（在解析 JSON 之前）
+	if resp.StatusCode != http.StatusOK {
+		return parseErrorResponse[T](resp, apiPath)
+	}
+
+	// 检查响应体是否为空
+	if resp.Result == nil && len(resp.RawBody) == 0 {
+		var zeroValue T
+		return NewSuccessResult("success", zeroValue), nil
+	}
+	if resp.Result == nil {
+		_, err := parseResponse[json.RawMessage](resp.RawBody)
+		return NewSysErrorResult[T]("failed to parse response"), fmt.Errorf("failed to parse response JSON from %s: %w", apiPath, err)
+	}
+
+	// 检查业务状态码
+	apiResponse := resp.Result
+	if apiResponse.Code != http.StatusOK {
+		return buildErrorResult[T](apiResponse, resp.StatusCode, apiPath)
+	}
+
+	// 解析 data
+	data, err := decodeData[T](apiResponse.Data)
 	if err != nil {
-		return NewSysErrorResult[T]("failed to generate auth header"), fmt.Errorf("failed to generate auth header: %w", err)
+		return NewSysErrorResult[T]("failed to parse response"), fmt.Errorf("failed to parse response JSON from %s: %w", apiPath, err)
 	}
 
+	// 返回成功结果
+	result := NewSuccessResult("success", data)
+	result.ErrCode = apiResponse.ErrCode
+	result.RawData = apiResponse.Data
+	return result, nil
+}
+
+// requestError 请求过程中的错误，message 用于构建 Result；retryable 表示是否属于可重试的传输错误
+type requestError struct {
+	message   string
+	err       error
+	retryable bool
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+
+func (e *requestError) Unwrap() error { return e.err }
+
+// transport 拦截器链末端：序列化请求体、发送 HTTP 请求并解析响应
+func (c *Client) transport(ctx context.Context, req *Request) (*Response, error) {
 	// 构建请求 URL
 	baseURL, err := url.Parse(c.config.Address)
 	if err != nil {
-		return NewSysErrorResult[T]("invalid base URL"), fmt.Errorf("invalid base URL: %w", err)
+		return nil, &requestError{"invalid base URL", fmt.Errorf("invalid base URL: %w", err), false}
 	}
 	// 使用 ResolveReference 或手动拼接路径，避免 path.Join 的问题
-	apiURL, err := url.Parse(apiPath)
+	apiURL, err := url.Parse(req.APIPath)
 	if err != nil {
-		return NewSysErrorResult[T]("invalid API path"), fmt.Errorf("invalid API path: %w", err)
+		return nil, &requestError{"invalid API path", fmt.Errorf("invalid API path: %w", err), false}
 	}
 	reqURL := baseURL.ResolveReference(apiURL).String()
 
 	// 序列化请求体
 	var bodyBytes []byte
-	if body != nil {
-		bodyBytes, err = json.Marshal(body)
+	if req.Body != nil {
+		bodyBytes, err = json.Marshal(req.Body)
 		if err != nil {
-			return NewSysErrorResult[T]("failed to marshal request body"), fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, &requestError{"failed to marshal request body", fmt.Errorf("failed to marshal request body: %w", err), false}
 		}
 	}
 
 	// 创建 HTTP 请求
-	httpReq, err := http.NewRequest(method, reqURL, bytes.NewBuffer(bodyBytes))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, reqURL, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return NewSysErrorResult[T]("failed to create request"), fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	// 设置 Header
-	httpReq.Header = header.Clone()
-	for k, v := range extraHeaders {
-		if v != "" {
-			httpReq.Header.Set(k, v)
-		}
+		return nil, &requestError{"failed to create request", fmt.Errorf("failed to create HTTP request: %w", err), false}
 	}
+	httpReq.Header = req.Header.Clone()
 
 	// 发送请求
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return NewSysErrorResult[T]("request failed"), fmt.Errorf("HTTP request failed: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// 读取响应
+	// 此时请求已到达服务端，仅可安全重试的接口才允许重试
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return NewSysErrorResult[T]("failed to read response"), fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// 检查 HTTP 状态码（在解析 JSON 之前）
-	if resp.StatusCode != http.StatusOK {
-		return parseErrorResponse[T](data, resp.StatusCode, apiPath)
+		return nil, &requestError{"failed to read response", fmt.Errorf("failed to read response body: %w", err), isRetrySafe(req.Method, req.APIPath)}
 	}
 
-	// 检查响应体是否为空
-	if len(data) == 0 {
-		var zeroValue T
-		return NewSuccessResult("success", zeroValue), nil
-	}
-
-	// 解析响应
-	apiResponse, err := parseResponse[T](data)
-	if err != nil {
-		return NewSysErrorResult[T]("failed to parse response"), fmt.Errorf("failed to parse response JSON from %s: %w", apiPath, err)
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RawBody:    data,
 	}
-
-	// 检查业务状态码
-	if apiResponse.Code != http.StatusOK {
-		return buildErrorResult(apiResponse, apiResponse.Code, apiPath, "business")
+	if len(data) > 0 {
+		if result, err := parseResponse[json.RawMessage](data); err == nil {
+			response.Result = result
+		}
 	}
-
-	// 返回成功结果
-	result := NewSuccessResult("success", apiResponse.Data)
-	result.ErrCode = apiResponse.ErrCode
-	return result, nil
+	return response, nil
 }
@@ -0,0 +1,100 @@
+package junyousdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// blockingServer 在请求 ctx 结束或测试结束前一直不返回
+func blockingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	t.Cleanup(func() {
+		close(done)
+		srv.Close()
+	})
+	return srv
+}
+
+func TestDoRequestContextCanceled(t *testing.T) {
+	srv := blockingServer(t)
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId("id").
+		WithAccessKey(junyoutest.DefaultAccessKey).
+		WithAddress(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	result, err := client.API().RegisterContext(ctx, &junyousdk.RegisterInfo{PhoneNumber: "13800138000"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if result == nil || result.Success {
+		t.Fatalf("result = %+v, want non-nil failed result", result)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("request returned after %v, want prompt return on cancel", elapsed)
+	}
+}
+
+func TestDoRequestContextDeadline(t *testing.T) {
+	srv := blockingServer(t)
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId("id").
+		WithAccessKey(junyoutest.DefaultAccessKey).
+		WithAddress(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// 查询接口可重试，ctx 超时后不应继续重试
+	result, err := client.API().GetEWTBalanceContext(ctx, 1, 10, "token")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if result == nil {
+		t.Fatal("result = nil, want non-nil")
+	}
+}
+
+func TestDoRequestContextSuccess(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := junyousdk.DoRequestContext[string](context.Background(), client,
+		http.MethodPost, junyousdk.APIPathRegister, &junyousdk.RegisterInfo{PhoneNumber: "13800138000"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || result.Data == "" {
+		t.Fatalf("result = %+v, want success with open_id", result)
+	}
+	if string(result.RawData) != `"`+result.Data+`"` {
+		t.Fatalf("RawData = %s, want %q", result.RawData, result.Data)
+	}
+}
@@ -1,6 +1,8 @@
 package junyousdk
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 )
 
@@ -11,6 +13,8 @@ type Result[T any] struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 	Data    T      `json:"data"`
+	// RawData 成功响应中 data 的原始字节，与服务端返回逐字节一致；不参与 JSON 序列化
+	RawData json.RawMessage `json:"-"`
 }
 
 // newResult 创建结果（内部辅助函数）
@@ -37,3 +41,27 @@ func NewSysErrorResult[T any](message string) *Result[T] {
 func NewParamErrorResult[T any](message string) *Result[T] {
 	return newResult(http.StatusBadRequest, false, message, *new(T))
 }
+
+// ErrEmptyPreSubmitMessage 预提交结果中没有可签名的 data
+var ErrEmptyPreSubmitMessage = errors.New("junyousdk: pre-submit result has no data")
+
+// PreSubmitMessage 由预提交结果（PreRewardGOC、PreCommitEWTReleaseByPartner 及其类型化版本）构建
+// CommitGOCRewardRequest / CommitEWTReleaseByPartnerRequest 的 Message 字段。
+// 直接使用 RawData 原始字节，不经过反序列化再序列化，避免键顺序、数字格式与转义变化导致签名内容与服务端不一致；
+// data 本身为 JSON 字符串时返回其内容。
+func PreSubmitMessage[T any](pre *Result[T]) (string, error) {
+	if pre == nil || len(pre.RawData) == 0 || isNullData(pre.RawData) {
+		return "", ErrEmptyPreSubmitMessage
+	}
+	if pre.RawData[0] == '"' {
+		var message string
+		if err := json.Unmarshal(pre.RawData, &message); err != nil {
+			return "", err
+		}
+		if message == "" {
+			return "", ErrEmptyPreSubmitMessage
+		}
+		return message, nil
+	}
+	return string(pre.RawData), nil
+}
@@ -0,0 +1,85 @@
+package junyousdk_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// rawDataServer 对所有请求返回 data 为给定原始 JSON 的成功响应
+func rawDataServer(t *testing.T, data string) *junyousdk.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{"code":200,"success":true,"message":"ok","data":` + data + `}}`))
+	}))
+	t.Cleanup(srv.Close)
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId("id").
+		WithAccessKey(junyoutest.DefaultAccessKey).
+		WithAddress(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestPreSubmitMessageIsByteExact(t *testing.T) {
+	// 键顺序、数字格式、转义与空白均与重新序列化的结果不同
+	data := `{"to":"0xb", "from":"0xa","amount":1.50,"biz_no":"GOC1","biz_desc":"企业 <奖励>","n":1e2}`
+	client := rawDataServer(t, data)
+
+	pre, err := client.API().PreRewardGOC(junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("1.50")}, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := junyousdk.PreSubmitMessage(pre)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message != data {
+		t.Fatalf("message = %s\nwant      %s", message, data)
+	}
+
+	typed, err := client.API().PreRewardGOCMessage(junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("1.50")}, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message, _ := junyousdk.PreSubmitMessage(typed); message != data {
+		t.Fatalf("typed message = %s, want %s", message, data)
+	}
+}
+
+func TestPreSubmitMessageStringData(t *testing.T) {
+	client := rawDataServer(t, `"{\"biz_no\":\"GOC1\",\"amount\":\"1.0\"}"`)
+	pre, err := junyousdk.DoRequest[string](client, http.MethodPost, junyousdk.APIPathGOCPreReward, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := junyousdk.PreSubmitMessage(pre)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"biz_no":"GOC1","amount":"1.0"}`; message != want {
+		t.Fatalf("message = %s, want %s", message, want)
+	}
+}
+
+func TestPreSubmitMessageEmpty(t *testing.T) {
+	for _, data := range []string{`null`, `""`} {
+		client := rawDataServer(t, data)
+		pre, err := junyousdk.DoRequest[string](client, http.MethodPost, junyousdk.APIPathGOCPreReward, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := junyousdk.PreSubmitMessage(pre); !errors.Is(err, junyousdk.ErrEmptyPreSubmitMessage) {
+			t.Fatalf("data %s: err = %v, want ErrEmptyPreSubmitMessage", data, err)
+		}
+	}
+	if _, err := junyousdk.PreSubmitMessage[map[string]any](nil); !errors.Is(err, junyousdk.ErrEmptyPreSubmitMessage) {
+		t.Fatalf("nil result: err = %v, want ErrEmptyPreSubmitMessage", err)
+	}
+}
@@ -0,0 +1,185 @@
+package junyousdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrJournalNotConfigured 未配置 Config.Journal
+var ErrJournalNotConfigured = errors.New("junyousdk: journal not configured")
+
+// ResumeAction Resume 对未完成操作的处理方式
+type ResumeAction string
+
+const (
+	// ResumeCommit 继续：签名（如需要）、提交，权证释放还会确认
+	ResumeCommit ResumeAction = "commit"
+	// ResumeAbandon 放弃：记录为 abandoned，不再提交；已签名或已提交的操作不能放弃
+	ResumeAbandon ResumeAction = "abandon"
+	// ResumeSkip 跳过：本次不处理，日志不变
+	ResumeSkip ResumeAction = "skip"
+)
+
+// ResumeOptions Resume 选项
+type ResumeOptions struct {
+	// MaxAge 开始时间早于 now - MaxAge 的未签名操作放弃而不提交（预提交通常有有效期）；0 表示不限。
+	// 已签名的操作不受影响，见 Resume
+	MaxAge time.Duration
+	// Decide 自定义每个操作的处理方式；设置后忽略 MaxAge。对已签名或已提交的操作返回 ResumeAbandon 时仍会提交
+	Decide func(op Operation) ResumeAction
+}
+
+// ResumeResult 单个操作的恢复结果
+type ResumeResult struct {
+	// Operation 恢复前的操作状态
+	Operation Operation
+	// Action 采取的处理方式
+	Action ResumeAction
+	// Phase 恢复后的阶段
+	Phase OperationPhase
+	// Err 处理失败的原因；失败的操作保留在日志中，下次 Resume 可再次处理
+	Err error
+}
+
+// Resume 读取 client 的 Config.Journal，逐个处理未完成的两阶段操作：
+//   - started：尚未取得 biz_no，记录为 abandoned；
+//   - pre_submitted：用 signer 对日志中的 message 签名后提交；
+//   - signed：用日志中的签名提交。此前的提交可能已被服务端接受（如网络错误后结果未知），
+//     因此总是重新提交而不放弃，由业务单号重复判定此前已成功；
+//   - committed（仅权证释放）：确认释放。
+//
+// 提交时若返回业务单号重复（ErrDuplicateBizNo），视为此前已提交成功。
+// 未签名的操作可按 ResumeOptions 放弃。单个操作失败不会中断其余操作，错误记录在对应的 ResumeResult.Err 中。
+// signer 仅在存在 pre_submitted 操作时需要，可为 nil。配置了 Config.IdempotencyStore 时，处理完毕后调用 Reconcile 更新幂等记录。
+func Resume(ctx context.Context, client *Client, signer Signer, opts ResumeOptions) ([]ResumeResult, error) {
+	journal := client.config.Journal
+	if journal == nil {
+		return nil, ErrJournalNotConfigured
+	}
+	ops, err := IncompleteOperations(ctx, journal)
+	if err != nil {
+		return nil, err
+	}
+
+	api := client.API()
+	results := make([]ResumeResult, 0, len(ops))
+	for _, op := range ops {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, api.resumeOperation(ctx, op, signer, opts))
+	}
+	if client.config.IdempotencyStore != nil {
+		if err := Reconcile(ctx, client); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// resumeAction 决定操作的处理方式
+func (s *APIService) resumeAction(op Operation, opts ResumeOptions) ResumeAction {
+	action := ResumeCommit
+	if opts.Decide != nil {
+		action = opts.Decide(op)
+	} else if opts.MaxAge > 0 && s.client.config.Clock.Now().Sub(op.StartedAt) > opts.MaxAge {
+		action = ResumeAbandon
+	}
+
+	switch {
+	case action == ResumeSkip:
+		return ResumeSkip
+	case op.Phase == PhaseCommitted:
+		// 已提交上链，只能继续确认
+		return ResumeCommit
+	case op.Phase == PhaseSigned:
+		// 可能已提交，放弃会丢失已上链的记录；重新提交，重复时按已提交处理
+		return ResumeCommit
+	case op.Phase == PhaseStarted || op.BizNo == "":
+		// 没有 biz_no，无法提交
+		return ResumeAbandon
+	default:
+		return action
+	}
+}
+
+// resumeOperation 处理单个未完成操作
+func (s *APIService) resumeOperation(ctx context.Context, op Operation, signer Signer, opts ResumeOptions) ResumeResult {
+	result := ResumeResult{Operation: op, Action: s.resumeAction(op, opts), Phase: op.Phase}
+	journal := &operationJournal{
+		journal: s.client.config.Journal,
+		clock:   s.client.config.Clock,
+		logger:  s.client.config.Logger,
+		id:      op.OperationId,
+		kind:    op.Kind,
+	}
+
+	switch result.Action {
+	case ResumeSkip:
+		return result
+	case ResumeAbandon:
+		if err := journal.record(ctx, JournalEntry{Phase: PhaseAbandoned}); err != nil {
+			result.Err = err
+			return result
+		}
+		result.Phase = PhaseAbandoned
+		return result
+	}
+
+	// 签名
+	if op.Phase == PhasePreSubmitted {
+		if signer == nil {
+			result.Err = fmt.Errorf("operation %s needs signing but no signer was given", op.OperationId)
+			return result
+		}
+		if op.Message == "" {
+			result.Err = fmt.Errorf("operation %s has no message to sign", op.OperationId)
+			return result
+		}
+		publicKey, derHex, err := signer.Sign([]byte(op.Message))
+		if err != nil {
+			result.Err = &FlowError{Step: FlowStepSign, BizNo: op.BizNo, Err: err}
+			return result
+		}
+		if err := journal.record(ctx, JournalEntry{Phase: PhaseSigned, PublicKey: publicKey, DerHex: derHex}); err != nil {
+			result.Err = err
+			return result
+		}
+		op.PublicKey, op.DerHex, op.Phase = publicKey, derHex, PhaseSigned
+		result.Phase = PhaseSigned
+	}
+
+	// 提交
+	if op.Phase == PhaseSigned {
+		commit, err := s.commitOperation(ctx, op.Kind, op.BizNo, op.Message, op.PublicKey, op.DerHex)
+		if err = flowResultError(commit, err); err != nil {
+			phase := commitFailurePhase(err)
+			journal.recordBestEffort(ctx, JournalEntry{Phase: phase, Error: err.Error()})
+			result.Phase = phase
+			if phase != PhaseCommitted {
+				result.Err = &FlowError{Step: FlowStepCommit, BizNo: op.BizNo, Err: err}
+				return result
+			}
+		} else {
+			journal.recordBestEffort(ctx, JournalEntry{Phase: PhaseCommitted, TxHash: commit.Data.TxHash})
+			result.Phase = PhaseCommitted
+		}
+		op.Phase = PhaseCommitted
+	}
+
+	// 确认（仅权证释放）
+	if op.Phase == PhaseCommitted && op.Kind == OperationEWTReleaseByPartner {
+		confirm, err := s.ConfirmEWTReleaseByPartnerContext(ctx, EWTBizNoInfo{EWTBizNo: op.BizNo})
+		if err = flowResultError(confirm, err); err != nil {
+			journal.recordBestEffort(ctx, JournalEntry{Phase: PhaseCommitted, Error: err.Error()})
+			result.Err = &FlowError{Step: FlowStepConfirm, BizNo: op.BizNo, Err: err}
+			return result
+		}
+		journal.recordBestEffort(ctx, JournalEntry{Phase: PhaseConfirmed})
+		result.Phase = PhaseConfirmed
+	}
+
+	return result
+}
@@ -0,0 +1,215 @@
+package junyousdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// lossyJournal 丢弃指定阶段的条目，模拟提交成功后进程在写入日志前退出
+type lossyJournal struct {
+	*junyousdk.MemoryJournal
+	drop junyousdk.OperationPhase
+}
+
+func (j *lossyJournal) Append(ctx context.Context, entry junyousdk.JournalEntry) error {
+	if entry.Phase == j.drop {
+		return errors.New("disk full")
+	}
+	return j.MemoryJournal.Append(ctx, entry)
+}
+
+// testClock 可手动推进的 Clock
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestResumeNeverAbandonsSignedOperation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts junyousdk.ResumeOptions
+	}{
+		{"max age", junyousdk.ResumeOptions{MaxAge: time.Minute}},
+		{"decide abandon", junyousdk.ResumeOptions{Decide: func(junyousdk.Operation) junyousdk.ResumeAction {
+			return junyousdk.ResumeAbandon
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := junyoutest.NewServer()
+			defer srv.Close()
+			clock := &testClock{now: time.Now()}
+			srv.SetClock(clock)
+			journal := &lossyJournal{MemoryJournal: junyousdk.NewMemoryJournal(), drop: junyousdk.PhaseCommitted}
+			client := newFlowClient(t, srv, srv.Config().WithJournal(journal).WithClock(clock))
+			openId := srv.AddUser("13800138000")
+
+			// 提交成功，但 committed 条目未写入：日志停留在 signed
+			report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("2.5"), newTestSigner(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ops, err := junyousdk.IncompleteOperations(context.Background(), journal)
+			if err != nil || len(ops) != 1 || ops[0].Phase != junyousdk.PhaseSigned {
+				t.Fatalf("incomplete = %+v, %v, want one signed operation", ops, err)
+			}
+
+			clock.Advance(time.Hour)
+			journal.drop = ""
+			results, err := junyousdk.Resume(context.Background(), client, nil, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("results = %+v, want one", results)
+			}
+			r := results[0]
+			if r.Action != junyousdk.ResumeCommit || r.Phase != junyousdk.PhaseCommitted || r.Err != nil {
+				t.Fatalf("result = %+v, want commit -> committed", r)
+			}
+			if r.Operation.BizNo != report.BizNo {
+				t.Fatalf("BizNo = %s, want %s", r.Operation.BizNo, report.BizNo)
+			}
+			// 重复提交被识别为已提交，没有重复发放
+			if got := srv.GOCBalance(openId); got != "2.5" {
+				t.Fatalf("GOCBalance = %s, want 2.5", got)
+			}
+			if ops, _ := junyousdk.IncompleteOperations(context.Background(), journal); len(ops) != 0 {
+				t.Fatalf("incomplete after resume = %+v, want none", ops)
+			}
+		})
+	}
+}
+
+func TestResumeRecommitsAfterUnknownCommitOutcome(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	journal := junyousdk.NewMemoryJournal()
+	client := newFlowClient(t, srv, srv.Config().WithJournal(journal))
+	receiver := srv.AddUser("13800138000")
+	srv.FailNext(junyousdk.APIPathEWTCommitReleaseByPartner, http.StatusBadGateway)
+
+	req := junyousdk.PreEWTReleaseByPartnerRequest{
+		Amount: junyousdk.MustParseAmount("100"),
+		Ratio:  junyousdk.MustParseRatio("0.5"),
+	}
+	report, err := client.API().ReleaseEWTByPartner(context.Background(), receiver, req, newTestSigner(t))
+	var flowErr *junyousdk.FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepCommit {
+		t.Fatalf("err = %v, want commit FlowError", err)
+	}
+
+	results, err := junyousdk.Resume(context.Background(), client, nil, junyousdk.ResumeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Phase != junyousdk.PhaseConfirmed || results[0].Err != nil {
+		t.Fatalf("results = %+v, want one confirmed", results)
+	}
+	if !srv.Committed(report.BizNo) {
+		t.Fatal("operation not committed by Resume")
+	}
+	if got := srv.EWTBalance(receiver); got != "50" {
+		t.Fatalf("EWTBalance = %s, want 50", got)
+	}
+}
+
+func TestResumeAbandonsUnsignedOperations(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	clock := &testClock{now: time.Now()}
+	srv.SetClock(clock)
+	journal := &lossyJournal{MemoryJournal: junyousdk.NewMemoryJournal(), drop: junyousdk.PhaseSigned}
+	client := newFlowClient(t, srv, srv.Config().WithJournal(journal).WithClock(clock))
+	openId := srv.AddUser("13800138000")
+
+	// 签名条目写入失败，流程在提交前中断，日志停留在 pre_submitted
+	report, err := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("1"), newTestSigner(t))
+	var flowErr *junyousdk.FlowError
+	if !errors.As(err, &flowErr) || flowErr.Step != junyousdk.FlowStepSign {
+		t.Fatalf("err = %v, want sign FlowError", err)
+	}
+	// 只有 started 的操作
+	if err := journal.Append(context.Background(), junyousdk.JournalEntry{
+		OperationId: "started-only",
+		Kind:        junyousdk.OperationGOCReward,
+		Phase:       junyousdk.PhaseStarted,
+		Time:        clock.Now(),
+		OpenId:      openId,
+		Amount:      "1",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(time.Hour)
+	results, err := junyousdk.Resume(context.Background(), client, nil, junyousdk.ResumeOptions{MaxAge: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want two", results)
+	}
+	for _, r := range results {
+		if r.Action != junyousdk.ResumeAbandon || r.Phase != junyousdk.PhaseAbandoned || r.Err != nil {
+			t.Fatalf("result = %+v, want abandoned", r)
+		}
+	}
+	if srv.Committed(report.BizNo) {
+		t.Fatal("abandoned operation committed")
+	}
+}
+
+func TestResumeSignsPreSubmittedOperation(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	journal := &lossyJournal{MemoryJournal: junyousdk.NewMemoryJournal(), drop: junyousdk.PhaseSigned}
+	client := newFlowClient(t, srv, srv.Config().WithJournal(journal))
+	openId := srv.AddUser("13800138000")
+	signer := newTestSigner(t)
+
+	report, _ := client.API().RewardGOCFlow(context.Background(), openId, junyousdk.MustParseAmount("1"), signer)
+	journal.drop = ""
+
+	// 未提供 signer 时报错并保留操作
+	results, err := junyousdk.Resume(context.Background(), client, nil, junyousdk.ResumeOptions{})
+	if err != nil || len(results) != 1 || results[0].Err == nil || results[0].Phase != junyousdk.PhasePreSubmitted {
+		t.Fatalf("results = %+v, %v, want error without signer", results, err)
+	}
+
+	results, err = junyousdk.Resume(context.Background(), client, signer, junyousdk.ResumeOptions{})
+	if err != nil || len(results) != 1 || results[0].Phase != junyousdk.PhaseCommitted || results[0].Err != nil {
+		t.Fatalf("results = %+v, %v, want committed", results, err)
+	}
+	if !srv.Committed(report.BizNo) || srv.GOCBalance(openId) != "1" {
+		t.Fatalf("committed = %v, balance = %s", srv.Committed(report.BizNo), srv.GOCBalance(openId))
+	}
+}
+
+func TestResumeWithoutJournal(t *testing.T) {
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().WithAccessId("id").WithAccessKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := junyousdk.Resume(context.Background(), client, nil, junyousdk.ResumeOptions{}); !errors.Is(err, junyousdk.ErrJournalNotConfigured) {
+		t.Fatalf("err = %v, want ErrJournalNotConfigured", err)
+	}
+}
@@ -0,0 +1,207 @@
+package junyousdk
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy 重试策略。
+// 每次重试都会重新生成签名 Header（X-Signature、X-Signature-Nonce、X-Timestamp），避免 nonce 重放或时间戳过期。
+//
+// 为避免重复提交，重试范围按接口区分：
+//   - GET 查询、EWT 预提交（PreCommitEWTReleaseByPartner）及 auth 令牌接口：网络错误和 RetryableStatusCodes 中的状态码均会重试；
+//   - 其余写接口（如 RewardGOC、CommitEWTReleaseByPartner）及 GOC 预提交（PreRewardGOC，所用 Open Token 仅可使用一次）：
+//     仅在能确定请求尚未到达服务端时（DNS 解析失败、建立连接失败）重试。
+type RetryPolicy struct {
+	// MaxAttempts 最大尝试次数（含首次请求），<= 1 表示不重试
+	MaxAttempts int
+	// InitialBackoff 第一次重试前的等待时间
+	InitialBackoff time.Duration
+	// MaxBackoff 单次等待时间上限
+	MaxBackoff time.Duration
+	// Multiplier 指数退避倍数（<= 1 时按 2 处理）
+	Multiplier float64
+	// Jitter 抖动比例，取值 [0, 1]；实际等待时间在 [d*(1-Jitter), d] 区间内随机
+	Jitter float64
+	// RetryableStatusCodes 可重试的 HTTP 状态码
+	RetryableStatusCodes []int
+	// HonorRetryAfter 为 true 时，响应携带 Retry-After 则按其等待
+	HonorRetryAfter bool
+	// MaxRetryAfter Retry-After 等待时间上限，超过时按上限等待；<= 0 时取 MaxBackoff，二者均 <= 0 时不限
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy 返回默认重试策略：最多 3 次尝试，200ms 起指数退避，上限 5s；Retry-After 同样以 5s 为上限
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		HonorRetryAfter: true,
+	}
+}
+
+// retrySafePaths 可在收到响应后安全重试的 POST 接口：重复调用只会生成新的令牌或待提交单据，不会产生资金变动。
+// GOC 预提交不在其中：其 Open Token 仅可使用一次，请求到达服务端后原样重发会因 Token 已使用而失败。
+var retrySafePaths = map[string]bool{
+	APIPathAuthLogin:                  true,
+	APIPathAuthSetPWD:                 true,
+	APIPathAuthCMT:                    true,
+	APIPathEWTPreOpenReleaseByPartner: true,
+}
+
+// isRetrySafe 判断接口是否可在请求已发出后重试
+func isRetrySafe(method, apiPath string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	if idx := strings.Index(apiPath, "?"); idx != -1 {
+		apiPath = apiPath[:idx]
+	}
+	return retrySafePaths[apiPath]
+}
+
+// isPreSendError 判断错误是否发生在请求到达服务端之前（DNS 解析或建立连接失败）
+func isPreSendError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return false
+}
+
+// Backoff 返回第 retry 次重试（从 1 开始）前的等待时间，已包含抖动
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	jitter := p.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	if jitter > 0 {
+		d -= d * jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// retryDelay 判断本次尝试后是否需要重试，返回等待时间。
+// statusCode 为 0 表示未收到响应（err 为传输错误）。
+func (p *RetryPolicy) retryDelay(attempt int, retrySafe bool, statusCode int, header http.Header, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		var reqErr *requestError
+		if errors.As(err, &reqErr) && !reqErr.retryable {
+			return 0, false
+		}
+		if !retrySafe && !isPreSendError(err) {
+			return 0, false
+		}
+		return p.Backoff(attempt), true
+	}
+
+	if !retrySafe || !p.isRetryableStatus(statusCode) {
+		return 0, false
+	}
+	if p.HonorRetryAfter {
+		if d, ok := parseRetryAfter(header.Get("Retry-After")); ok {
+			return p.capRetryAfter(d), true
+		}
+	}
+	return p.Backoff(attempt), true
+}
+
+// capRetryAfter 将 Retry-After 等待时间限制在 MaxRetryAfter（未设置时为 MaxBackoff）以内，避免服务端令调用方无限期等待
+func (p *RetryPolicy) capRetryAfter(d time.Duration) time.Duration {
+	limit := p.MaxRetryAfter
+	if limit <= 0 {
+		limit = p.MaxBackoff
+	}
+	if limit > 0 && d > limit {
+		return limit
+	}
+	return d
+}
+
+// isRetryableStatus 判断 HTTP 状态码是否可重试
+func (p *RetryPolicy) isRetryableStatus(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter 解析 Retry-After（秒数或 HTTP 日期）
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleepContext 等待 d，ctx 取消时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
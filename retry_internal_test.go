@@ -0,0 +1,139 @@
+package junyousdk
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.Backoff(tt.retry); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.retry, got, tt.want)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.Backoff(2); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("Backoff(2) with jitter = %v, want within [100ms, 200ms]", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{" 0 ", 0, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got, ok := parseRetryAfter(future); !ok || got < 59*time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %v, %v; want about 1h", future, got, ok)
+	}
+}
+
+func TestRetryDelayCapsRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"3600"}}
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   time.Duration
+	}{
+		{"MaxBackoff", RetryPolicy{MaxAttempts: 3, MaxBackoff: 5 * time.Second}, 5 * time.Second},
+		{"MaxRetryAfter", RetryPolicy{MaxAttempts: 3, MaxBackoff: 5 * time.Second, MaxRetryAfter: 30 * time.Second}, 30 * time.Second},
+		{"Unlimited", RetryPolicy{MaxAttempts: 3}, time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.policy
+			p.HonorRetryAfter = true
+			p.RetryableStatusCodes = []int{http.StatusServiceUnavailable}
+			got, ok := p.retryDelay(1, true, http.StatusServiceUnavailable, header, nil)
+			if !ok || got != tt.want {
+				t.Fatalf("retryDelay = %v, %v; want %v, true", got, ok, tt.want)
+			}
+		})
+	}
+
+	short := http.Header{"Retry-After": []string{"1"}}
+	p := DefaultRetryPolicy()
+	if got, ok := p.retryDelay(1, true, http.StatusServiceUnavailable, short, nil); !ok || got != time.Second {
+		t.Fatalf("retryDelay below cap = %v, %v; want 1s, true", got, ok)
+	}
+}
+
+func TestRetryDelayScope(t *testing.T) {
+	p := DefaultRetryPolicy()
+	dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Err: errors.New("connection reset")}
+
+	tests := []struct {
+		name      string
+		attempt   int
+		retrySafe bool
+		status    int
+		err       error
+		want      bool
+	}{
+		{"safe 503", 1, true, http.StatusServiceUnavailable, nil, true},
+		{"safe 500", 1, true, http.StatusInternalServerError, nil, false},
+		{"unsafe 503", 1, false, http.StatusServiceUnavailable, nil, false},
+		{"unsafe dial error", 1, false, 0, dialErr, true},
+		{"unsafe read error", 1, false, 0, readErr, false},
+		{"safe read error", 1, true, 0, readErr, true},
+		{"non-retryable request error", 1, true, 0, &requestError{"x", errors.New("x"), false}, false},
+		{"attempts exhausted", 3, true, http.StatusServiceUnavailable, nil, false},
+	}
+	for _, tt := range tests {
+		if _, got := p.retryDelay(tt.attempt, tt.retrySafe, tt.status, nil, tt.err); got != tt.want {
+			t.Errorf("%s: retry = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsRetrySafe(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodGet, APIPathEWTBalance, true},
+		{http.MethodPost, APIPathGOCPreReward, false},
+		{http.MethodPost, APIPathEWTPreOpenReleaseByPartner, true},
+		{http.MethodPost, APIPathAuthLogin + "?x=1", true},
+		{http.MethodPost, APIPathGOCReward, false},
+		{http.MethodPost, APIPathEWTCommitReleaseByPartner, false},
+	}
+	for _, tt := range tests {
+		if got := isRetrySafe(tt.method, tt.path); got != tt.want {
+			t.Errorf("isRetrySafe(%s, %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
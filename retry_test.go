@@ -0,0 +1,182 @@
+package junyousdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// fastRetryPolicy 测试用重试策略，退避时间很短
+func fastRetryPolicy() *junyousdk.RetryPolicy {
+	p := junyousdk.DefaultRetryPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxBackoff = 10 * time.Millisecond
+	return p
+}
+
+func TestRetryResignsEveryAttempt(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config().WithRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+
+	srv.FailNext(junyousdk.APIPathAuthLogin, http.StatusServiceUnavailable, http.StatusBadGateway)
+	result, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: openId})
+	if err != nil || !result.Success {
+		t.Fatalf("AuthLogin = %+v, %v; want success after retries", result, err)
+	}
+
+	nonces := map[string]bool{}
+	for _, r := range srv.Requests() {
+		if r.Path != junyousdk.APIPathAuthLogin {
+			continue
+		}
+		nonces[r.Header.Get(junyousdk.HeaderNonce)] = true
+	}
+	if len(nonces) != 3 {
+		t.Fatalf("distinct nonces = %d, want 3 (one per attempt)", len(nonces))
+	}
+}
+
+func TestRetrySkipsUnsafeCommit(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config().WithRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.FailNext(junyousdk.APIPathGOCReward, http.StatusServiceUnavailable)
+	result, err := client.API().RewardGOC(junyousdk.CommitGOCRewardRequest{
+		BizNo: "GOC1", Message: "{}", PublicKey: "04", DerHex: "30",
+	})
+	if err == nil || result.Code != http.StatusServiceUnavailable {
+		t.Fatalf("RewardGOC = %+v, %v; want 503 without retry", result, err)
+	}
+	count := 0
+	for _, r := range srv.Requests() {
+		if r.Path == junyousdk.APIPathGOCReward {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Fatalf("commit requests = %d, want 1", count)
+	}
+}
+
+func TestRetryAfterIsCapped(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"result":{"code":200,"success":true,"message":"ok","data":"token"}}`))
+	}))
+	defer srv.Close()
+
+	policy := fastRetryPolicy()
+	policy.MaxRetryAfter = 20 * time.Millisecond
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId("id").
+		WithAccessKey(junyoutest.DefaultAccessKey).
+		WithAddress(srv.URL).
+		WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	result, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: "x"})
+	if err != nil || result.Data != "token" {
+		t.Fatalf("AuthLogin = %+v, %v", result, err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("waited %v, want Retry-After capped at MaxRetryAfter", elapsed)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("calls = %d, want 2", calls.Load())
+	}
+}
+
+func TestRetrySkipsGOCPreReward(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config().WithRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+
+	srv.FailNext(junyousdk.APIPathGOCPreReward, http.StatusServiceUnavailable)
+	result, err := client.API().PreRewardGOCByOpenId(junyousdk.PreGOCRewardRequest{
+		Amount: junyousdk.MustParseAmount("1.00"),
+	}, openId)
+	if err == nil || result.Code != http.StatusServiceUnavailable {
+		t.Fatalf("PreRewardGOCByOpenId = %+v, %v; want 503 without retry", result, err)
+	}
+	count := 0
+	for _, r := range srv.Requests() {
+		if r.Path == junyousdk.APIPathGOCPreReward {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Fatalf("pre-submit requests = %d, want 1", count)
+	}
+}
+
+func TestRetryReadFailureOnlyOnSafePaths(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		// 声明的长度大于实际写入，客户端读取响应体时出错
+		w.Header().Set("Content-Length", "100")
+		w.Write([]byte(`{"result":`))
+	}))
+	defer srv.Close()
+
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId("id").
+		WithAccessKey(junyoutest.DefaultAccessKey).
+		WithAddress(srv.URL).
+		WithRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		call  func() error
+		wantN int32
+	}{
+		{"commit", func() error {
+			_, err := client.API().RewardGOC(junyousdk.CommitGOCRewardRequest{
+				BizNo: "GOC1", Message: "{}", PublicKey: "04", DerHex: "30",
+			})
+			return err
+		}, 1},
+		{"login", func() error {
+			_, err := client.API().AuthLogin(junyousdk.OpenIdToken{OpenId: "x"})
+			return err
+		}, 3},
+	}
+	for _, tt := range tests {
+		calls.Store(0)
+		if err := tt.call(); err == nil {
+			t.Fatalf("%s: want read error", tt.name)
+		}
+		if got := calls.Load(); got != tt.wantN {
+			t.Errorf("%s: calls = %d, want %d", tt.name, got, tt.wantN)
+		}
+	}
+}
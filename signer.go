@@ -0,0 +1,160 @@
+package junyousdk
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/junyouava/junyou-sdk-go/keys"
+)
+
+// Signer 链上业务消息签名器，用于 GOC / EWT 提交前对预提交 message 签名。
+// 返回值直接填入 CommitGOCRewardRequest / CommitEWTReleaseByPartnerRequest 的 PublicKey 与 DerHex。
+type Signer interface {
+	// Sign 对 message 原始字节签名，返回未压缩公钥十六进制与 DER 签名十六进制
+	Sign(message []byte) (pubKeyHex, derHex string, err error)
+}
+
+// SignerFunc 函数形式的 Signer，便于接入密盾、KMS 等外部签名服务
+type SignerFunc func(message []byte) (pubKeyHex, derHex string, err error)
+
+// Sign 实现 Signer
+func (f SignerFunc) Sign(message []byte) (string, string, error) {
+	return f(message)
+}
+
+// 私钥加载错误；与 keys 包的同名错误为同一值
+var (
+	// ErrInvalidPrivateKey 私钥格式错误或不在 secp256k1 有效范围内
+	ErrInvalidPrivateKey = keys.ErrInvalidPrivateKey
+	// ErrUnsupportedKeyCurve PEM 私钥不是 secp256k1 曲线
+	ErrUnsupportedKeyCurve = keys.ErrUnsupportedCurve
+)
+
+// LocalSigner 本地 secp256k1 私钥签名器，曲线运算由 keys 包（decred secp256k1）完成：
+// 对 message 做 SHA-256 摘要，按 RFC 6979 生成确定性 k，输出 low-S 签名的 DER 编码；
+// 公钥为 04 开头的未压缩十六进制（130 个字符），十六进制均为小写。
+//
+// Open API 文档未说明链上验签使用的摘要算法；SHA-256 是 secp256k1 DER 签名的常见约定，接入前应与平台确认。
+// 文档中的提交示例使用 P-256 公钥与 high-S DER 签名，说明服务端可能接受其他曲线与 high-S 签名；
+// LocalSigner 仅支持 secp256k1，low-S 输出对接受 high-S 的验签方同样有效。
+type LocalSigner struct {
+	key *keys.PrivateKey
+}
+
+// NewLocalSigner 由 32 字节私钥创建本地签名器
+func NewLocalSigner(privateKey []byte) (*LocalSigner, error) {
+	key, err := keys.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalSignerFromKey(key), nil
+}
+
+// NewLocalSignerFromHex 由十六进制私钥（64 个字符，可带 0x 前缀）创建本地签名器
+func NewLocalSignerFromHex(privateKeyHex string) (*LocalSigner, error) {
+	key, err := keys.ParsePrivateKeyHex(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalSignerFromKey(key), nil
+}
+
+// NewLocalSignerFromPEM 由 PEM 私钥创建本地签名器，支持 SEC1（EC PRIVATE KEY）与 PKCS#8（PRIVATE KEY），
+// 曲线须为 secp256k1；openssl 输出中的 EC PARAMETERS 块会被跳过
+func NewLocalSignerFromPEM(pemBytes []byte) (*LocalSigner, error) {
+	key, err := keys.ParsePrivateKeyPEM(pemBytes)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalSignerFromKey(key), nil
+}
+
+// NewLocalSignerFromKey 由 keys.PrivateKey 创建本地签名器
+func NewLocalSignerFromKey(key *keys.PrivateKey) *LocalSigner {
+	return &LocalSigner{key: key}
+}
+
+// GenerateLocalSigner 随机生成私钥并创建本地签名器
+func GenerateLocalSigner() (*LocalSigner, error) {
+	key, err := keys.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalSignerFromKey(key), nil
+}
+
+// PublicKeyHex 返回未压缩公钥十六进制
+func (s *LocalSigner) PublicKeyHex() string {
+	return s.key.PublicKey().UncompressedHex()
+}
+
+// Address 返回公钥对应的链上地址，可与预提交消息的 from 比较，见 keys.PublicKey.Address
+func (s *LocalSigner) Address() string {
+	return s.key.PublicKey().Address()
+}
+
+// Sign 实现 Signer
+func (s *LocalSigner) Sign(message []byte) (string, string, error) {
+	sig, err := s.key.SignMessage(message)
+	if err != nil {
+		return "", "", err
+	}
+	return s.PublicKeyHex(), sig.DERHex(), nil
+}
+
+// String 仅输出公钥，避免私钥经 fmt 打印
+func (s *LocalSigner) String() string {
+	return "LocalSigner(" + s.PublicKeyHex() + ")"
+}
+
+// LogValue 实现 slog.LogValuer，私钥不会出现在日志中
+func (s *LocalSigner) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("public_key", s.PublicKeyHex()),
+		slog.String("private_key", redacted),
+	)
+}
+
+// ErrChainSignatureInvalid message 签名未通过本地校验
+var ErrChainSignatureInvalid = errors.New("junyousdk: chain signature invalid")
+
+// VerifyChainSignature 在本地校验 message 签名：secp256k1 曲线，对 message 做 SHA-256 摘要（与 LocalSigner 相同的约定），
+// high-S 与 low-S 签名均接受。
+// publicKeyHex 须为 04 开头的未压缩公钥十六进制，derHex 为 DER 签名十六进制（与 Signer 返回值相同）。
+// 失败时返回包装 ErrChainSignatureInvalid 的错误，说明是公钥格式、签名编码错误，
+// 还是签名与 message、公钥不匹配（私钥与公钥不对应或 message 已改变）。
+func VerifyChainSignature(message, publicKeyHex, derHex string) error {
+	pub, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		return fmt.Errorf("%w: public key is not hex: %v", ErrChainSignatureInvalid, err)
+	}
+	if len(pub) != 65 || pub[0] != 0x04 {
+		return fmt.Errorf("%w: public key must be 65-byte uncompressed (04 prefix), got %d bytes", ErrChainSignatureInvalid, len(pub))
+	}
+	key, err := keys.ParsePublicKey(pub)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrChainSignatureInvalid, err)
+	}
+	der, err := hex.DecodeString(derHex)
+	if err != nil {
+		return fmt.Errorf("%w: der_hex is not hex: %v", ErrChainSignatureInvalid, err)
+	}
+	sig, err := keys.ParseDER(der)
+	if err != nil {
+		return fmt.Errorf("%w: malformed DER signature: %v", ErrChainSignatureInvalid, err)
+	}
+	if !key.VerifyMessage([]byte(message), sig) {
+		return fmt.Errorf("%w: signature does not match message and public key", ErrChainSignatureInvalid)
+	}
+	return nil
+}
+
+// checkCommitSignature 配置了 Config.VerifyCommitSignature 时在提交前校验签名
+func (s *APIService) checkCommitSignature(message, publicKey, derHex string) error {
+	if !s.client.config.VerifyCommitSignature {
+		return nil
+	}
+	return VerifyChainSignature(message, publicKey, derHex)
+}
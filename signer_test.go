@@ -0,0 +1,219 @@
+package junyousdk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+	"github.com/junyouava/junyou-sdk-go/keys"
+)
+
+// keyOneHex 私钥 1，公钥为基点 G，用于与公开测试向量比对
+var keyOneHex = strings.Repeat("0", 63) + "1"
+
+func TestLocalSignerKnownAnswer(t *testing.T) {
+	signer, err := junyousdk.NewLocalSignerFromHex(keyOneHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, der, err := signer.Sign([]byte("Satoshi Nakamoto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		wantPub = "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+			"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+		wantDER = "3045022100934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8" +
+			"02202442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"
+	)
+	if pub != wantPub {
+		t.Errorf("public key = %s, want %s", pub, wantPub)
+	}
+	if der != wantDER {
+		t.Errorf("der = %s, want %s", der, wantDER)
+	}
+}
+
+func TestLocalSignerLowSAndDeterministic(t *testing.T) {
+	signer, err := junyousdk.NewLocalSignerFromPEM(mustReadFile(t, "keys/testdata/sec1.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey, err := keys.ParsePublicKeyHex(signer.PublicKeyHex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 64; i++ {
+		message := []byte(fmt.Sprintf(`{"biz_no":"GOC%d","amount":"1.00"}`, i))
+		pub, der, err := signer.Sign(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pub != signer.PublicKeyHex() || len(pub) != 130 || !strings.HasPrefix(pub, "04") {
+			t.Fatalf("public key = %s", pub)
+		}
+		sig, err := keys.ParseDERHex(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sig.IsLowS() {
+			t.Fatalf("signature %d is high-S: %s", i, der)
+		}
+		if !pubKey.VerifyMessage(message, sig) {
+			t.Fatalf("signature %d does not verify", i)
+		}
+		if _, again, _ := signer.Sign(message); again != der {
+			t.Fatalf("signature %d is not deterministic", i)
+		}
+	}
+}
+
+func TestLocalSignerInvalidKeys(t *testing.T) {
+	if _, err := junyousdk.NewLocalSigner(make([]byte, 32)); !errors.Is(err, junyousdk.ErrInvalidPrivateKey) {
+		t.Errorf("zero key: err = %v, want ErrInvalidPrivateKey", err)
+	}
+	if _, err := junyousdk.NewLocalSigner(make([]byte, 31)); !errors.Is(err, junyousdk.ErrInvalidPrivateKey) {
+		t.Errorf("short key: err = %v, want ErrInvalidPrivateKey", err)
+	}
+	p256 := mustReadFile(t, "keys/testdata/p256_public.pem")
+	if _, err := junyousdk.NewLocalSignerFromPEM(p256); !errors.Is(err, junyousdk.ErrInvalidPrivateKey) {
+		t.Errorf("public key PEM: err = %v, want ErrInvalidPrivateKey", err)
+	}
+}
+
+func TestLocalSignerDoesNotLeakKey(t *testing.T) {
+	const keyHex = "946f29bd204ee9d2c546b2ef0b04b639ad270c164a2442864fd5c0c40bc8d3f8"
+	signer, err := junyousdk.NewLocalSignerFromHex(keyHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("signer", "signer", signer)
+	for _, out := range []string{signer.String(), fmt.Sprintf("%v %+v", signer, signer), buf.String()} {
+		if strings.Contains(out, keyHex) {
+			t.Fatalf("output leaks private key: %s", out)
+		}
+	}
+}
+
+func mustReadFile(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVerifyChainSignature(t *testing.T) {
+	// 与 keys/testdata 中 openssl 生成的签名同一私钥，其中包含 high-S 签名
+	signer, err := junyousdk.NewLocalSignerFromPEM(mustReadFile(t, "keys/testdata/sec1.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := signer.PublicKeyHex()
+	const message = "hello chain"
+
+	var highS int
+	for _, derHex := range strings.Fields(string(mustReadFile(t, "keys/testdata/openssl_sigs.txt"))) {
+		if err := junyousdk.VerifyChainSignature(message, publicKey, derHex); err != nil {
+			t.Errorf("openssl signature %s: %v", derHex, err)
+		}
+		if sig, _ := keys.ParseDERHex(derHex); !sig.IsLowS() {
+			highS++
+		}
+		if err := junyousdk.VerifyChainSignature(message+"!", publicKey, derHex); !errors.Is(err, junyousdk.ErrChainSignatureInvalid) {
+			t.Errorf("signature %s verified a different message: %v", derHex, err)
+		}
+	}
+	if highS == 0 {
+		t.Fatal("fixtures contain no high-S signature")
+	}
+
+	// LocalSigner 的签名可通过校验
+	pub, der, err := signer.Sign([]byte(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := junyousdk.VerifyChainSignature(message, pub, der); err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := junyousdk.NewLocalSignerFromHex(keyOneHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := keys.ParsePrivateKeyHex(keyOneHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := []struct {
+		name, publicKey, derHex string
+	}{
+		{"other public key", other.PublicKeyHex(), der},
+		{"compressed public key", key.PublicKey().CompressedHex(), der},
+		{"public key not hex", "04zz", der},
+		{"der not hex", pub, "30zz"},
+		{"malformed der", pub, "3000"},
+	}
+	for _, tt := range invalid {
+		if err := junyousdk.VerifyChainSignature(message, tt.publicKey, tt.derHex); !errors.Is(err, junyousdk.ErrChainSignatureInvalid) {
+			t.Errorf("%s: err = %v, want ErrChainSignatureInvalid", tt.name, err)
+		}
+	}
+}
+
+func TestCommitRejectsInvalidSignature(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config().WithVerifyCommitSignature(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := newTestSigner(t)
+	pub, der, err := signer.Sign([]byte(`{"biz_no":"B1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	goc := junyousdk.CommitGOCRewardRequest{BizNo: "B1", Message: `{"biz_no":"B2"}`, PublicKey: pub, DerHex: der}
+	ewt := junyousdk.CommitEWTReleaseByPartnerRequest{BizNo: "B1", Message: `{"biz_no":"B2"}`, PublicKey: pub, DerHex: der}
+
+	check := func(name string, success bool, code int, err error) {
+		t.Helper()
+		if success || code != http.StatusBadRequest || !errors.Is(err, junyousdk.ErrChainSignatureInvalid) {
+			t.Errorf("%s: success %v, code %d, err %v; want param error result with ErrChainSignatureInvalid", name, success, code, err)
+		}
+	}
+	if r, err := client.API().RewardGOCContext(ctx, goc); r != nil {
+		check("RewardGOC", r.Success, r.Code, err)
+	} else {
+		t.Error("RewardGOC returned nil result")
+	}
+	if r, err := client.API().RewardGOCReceiptContext(ctx, goc); r != nil {
+		check("RewardGOCReceipt", r.Success, r.Code, err)
+	} else {
+		t.Error("RewardGOCReceipt returned nil result")
+	}
+	if r, err := client.API().CommitEWTReleaseByPartnerContext(ctx, ewt); r != nil {
+		check("CommitEWTReleaseByPartner", r.Success, r.Code, err)
+	} else {
+		t.Error("CommitEWTReleaseByPartner returned nil result")
+	}
+	if r, err := client.API().CommitEWTReleaseByPartnerReceiptContext(ctx, ewt); r != nil {
+		check("CommitEWTReleaseByPartnerReceipt", r.Success, r.Code, err)
+	} else {
+		t.Error("CommitEWTReleaseByPartnerReceipt returned nil result")
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want none", n)
+	}
+}
@@ -0,0 +1,219 @@
+package junyousdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenPolicy Open Token 取用策略
+type TokenPolicy int
+
+const (
+	// TokenCached 复用有效期内的缓存 Token，无缓存时登录；适用于余额、交易明细等只读查询
+	TokenCached TokenPolicy = iota
+	// TokenFresh 每次重新 AuthLogin 换取新 Token，不读写缓存；适用于 GOC、权证预提交
+	TokenFresh
+)
+
+// TokenManager 按 open_id 管理 Open Token（X-Open-Auth）：
+// 只读查询复用 Config.TokenTTL 内的缓存 Token，同一 open_id 的并发登录合并为一次；
+// 要求新 Token 的预提交每次重新登录。并发安全，由 Client.Tokens 返回。
+type TokenManager struct {
+	api   *APIService
+	clock Clock
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	tokens   map[string]cachedToken
+	inflight map[string]*tokenCall
+}
+
+// cachedToken 缓存的 Open Token
+type cachedToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// tokenCall 进行中的登录，同一 open_id 的其他调用者等待其结果
+type tokenCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// NewTokenManager 创建 TokenManager；ttl 非正时取 DefaultTokenTTL
+func NewTokenManager(client *Client, ttl time.Duration) *TokenManager {
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+	return &TokenManager{
+		api:      client.api,
+		clock:    client.config.Clock,
+		ttl:      ttl,
+		tokens:   map[string]cachedToken{},
+		inflight: map[string]*tokenCall{},
+	}
+}
+
+// Token 返回 openId 的缓存 Token；缓存不存在或已过期时登录并缓存
+func (m *TokenManager) Token(ctx context.Context, openId string) (string, error) {
+	openId = strings.TrimSpace(openId)
+	if openId == "" {
+		return "", errors.New("open_id is required")
+	}
+
+	for {
+		m.mu.Lock()
+		if cached, ok := m.tokens[openId]; ok && m.clock.Now().Before(cached.expiresAt) {
+			m.mu.Unlock()
+			return cached.token, nil
+		}
+		if call, ok := m.inflight[openId]; ok {
+			m.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			// 发起登录的调用者被取消时，由仍有效的调用者重新登录
+			if call.err != nil && isContextError(call.err) && ctx.Err() == nil {
+				continue
+			}
+			return call.token, call.err
+		}
+		call := &tokenCall{done: make(chan struct{})}
+		m.inflight[openId] = call
+		m.mu.Unlock()
+
+		call.token, call.err = m.login(ctx, openId)
+
+		m.mu.Lock()
+		delete(m.inflight, openId)
+		if call.err == nil {
+			m.tokens[openId] = cachedToken{token: call.token, expiresAt: m.clock.Now().Add(m.ttl)}
+		}
+		m.mu.Unlock()
+		close(call.done)
+		return call.token, call.err
+	}
+}
+
+// FreshToken 为 openId 重新登录换取新 Token，不读写缓存
+func (m *TokenManager) FreshToken(ctx context.Context, openId string) (string, error) {
+	openId = strings.TrimSpace(openId)
+	if openId == "" {
+		return "", errors.New("open_id is required")
+	}
+	return m.login(ctx, openId)
+}
+
+// TokenFor 按 policy 返回 openId 的 Token
+func (m *TokenManager) TokenFor(ctx context.Context, openId string, policy TokenPolicy) (string, error) {
+	if policy == TokenFresh {
+		return m.FreshToken(ctx, openId)
+	}
+	return m.Token(ctx, openId)
+}
+
+// Invalidate 删除 openId 的缓存 Token，下次 Token 调用重新登录
+func (m *TokenManager) Invalidate(openId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tokens, strings.TrimSpace(openId))
+}
+
+// InvalidateAll 清空全部缓存 Token
+func (m *TokenManager) InvalidateAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens = map[string]cachedToken{}
+}
+
+// login 调用 AuthLogin 换取 Token
+func (m *TokenManager) login(ctx context.Context, openId string) (string, error) {
+	result, err := m.api.AuthLoginContext(ctx, OpenIdToken{OpenId: openId})
+	if err = flowResultError(result, err); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(result.Data) == "" {
+		return "", errors.New("auth login returned an empty open token")
+	}
+	return result.Data, nil
+}
+
+// isContextError 判断 err 是否由 context 取消或超时引起
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// ReloginEvent 自动重新登录事件，见 Config.AutoRelogin
+type ReloginEvent struct {
+	// OpenId 被拒绝 Token 的用户
+	OpenId string
+	// Policy 调用使用的 Token 策略
+	Policy TokenPolicy
+	// Cause 服务端拒绝 Token 的错误（ErrOpenAuthInvalid 或 ErrMissingUserIdentity）
+	Cause error
+	// Err 重新登录失败的原因；nil 表示已换取新 Token 并将重放请求
+	Err error
+}
+
+// isTokenRejected 判断 err 是否为服务端拒绝 X-Open-Auth
+func isTokenRejected(err error) bool {
+	return errors.Is(err, ErrOpenAuthInvalid) || errors.Is(err, ErrMissingUserIdentity)
+}
+
+// invalidateToken 仅当缓存的仍是 token 时删除，避免并发调用删除其他调用者刚换取的新 Token
+func (m *TokenManager) invalidateToken(openId, token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cached, ok := m.tokens[openId]; ok && cached.token == token {
+		delete(m.tokens, openId)
+	}
+}
+
+// tokenErrorResult 取 Token 失败时的 Result：openId 为空时为参数错误；登录接口返回错误时沿用其状态码与业务错误代码，其余为系统错误
+func tokenErrorResult[T any](openId string, err error) *Result[T] {
+	message := "auth login failed: " + err.Error()
+	if strings.TrimSpace(openId) == "" {
+		return NewParamErrorResult[T](message)
+	}
+	result := NewSysErrorResult[T](message)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		result.Code = apiErr.StatusCode
+		if apiErr.StatusCode == http.StatusOK {
+			result.Code = apiErr.Code
+		}
+		result.ErrCode = apiErr.ErrCode
+	}
+	return result
+}
+
+// callByOpenId 按 policy 取得 openId 的 Token 后以其作为 openAuth 调用 call；
+// 开启 Config.AutoRelogin 时，Token 被拒绝则重新登录并重放一次
+func callByOpenId[T any](ctx context.Context, s *APIService, openId string, policy TokenPolicy, call func(openAuth string) (*Result[T], error)) (*Result[T], error) {
+	tokens := s.client.tokens
+	openAuth, err := tokens.TokenFor(ctx, openId, policy)
+	if err != nil {
+		return tokenErrorResult[T](openId, err), err
+	}
+	result, err := call(openAuth)
+	if err == nil || !s.client.config.AutoRelogin || !isTokenRejected(err) {
+		return result, err
+	}
+
+	tokens.invalidateToken(strings.TrimSpace(openId), openAuth)
+	newAuth, loginErr := tokens.TokenFor(ctx, openId, policy)
+	if hook := s.client.config.OnRelogin; hook != nil {
+		hook(ctx, ReloginEvent{OpenId: openId, Policy: policy, Cause: err, Err: loginErr})
+	}
+	if loginErr != nil {
+		return result, err
+	}
+	return call(newAuth)
+}
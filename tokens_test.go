@@ -0,0 +1,223 @@
+package junyousdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// loginCount 返回模拟服务收到的 AuthLogin 请求数
+func loginCount(srv *junyoutest.Server) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Path == junyousdk.APIPathAuthLogin {
+			n++
+		}
+	}
+	return n
+}
+
+// blockLogin 令第一次 AuthLogin 在 release 关闭或请求 ctx 结束前阻塞；entered 在其开始阻塞时关闭
+func blockLogin(client *junyousdk.Client) (entered, release chan struct{}) {
+	entered, release = make(chan struct{}), make(chan struct{})
+	var calls atomic.Int32
+	client.Use(func(next junyousdk.Handler) junyousdk.Handler {
+		return func(ctx context.Context, req *junyousdk.Request) (*junyousdk.Response, error) {
+			if req.APIPath == junyousdk.APIPathAuthLogin && calls.Add(1) == 1 {
+				close(entered)
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return next(ctx, req)
+		}
+	})
+	return entered, release
+}
+
+func TestTokenManagerCachesToken(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	tokens := client.Tokens()
+	ctx := context.Background()
+
+	first, err := tokens.Token(ctx, openId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := tokens.Token(ctx, " "+openId+" ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second || loginCount(srv) != 1 {
+		t.Fatalf("tokens %s / %s after %d logins, want one cached token", first, second, loginCount(srv))
+	}
+
+	// FreshToken 每次登录，且不影响缓存
+	fresh, err := tokens.TokenFor(ctx, openId, junyousdk.TokenFresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh == first || loginCount(srv) != 2 {
+		t.Fatalf("fresh token %s after %d logins, want new token", fresh, loginCount(srv))
+	}
+	if cached, _ := tokens.Token(ctx, openId); cached != first {
+		t.Fatalf("cached token = %s, want %s", cached, first)
+	}
+
+	tokens.Invalidate(openId)
+	renewed, err := tokens.Token(ctx, openId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed == first || loginCount(srv) != 3 {
+		t.Fatalf("token after Invalidate = %s after %d logins, want new token", renewed, loginCount(srv))
+	}
+
+	if _, err := tokens.Token(ctx, " "); err == nil {
+		t.Fatal("empty open_id accepted")
+	}
+}
+
+func TestTokenManagerExpires(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	clock := &testClock{now: time.Now()}
+	srv.SetClock(clock)
+	client, err := junyousdk.NewClient(srv.Config().WithClock(clock).WithTokenTTL(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	ctx := context.Background()
+
+	first, err := client.Tokens().Token(ctx, openId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(59 * time.Second)
+	if cached, _ := client.Tokens().Token(ctx, openId); cached != first {
+		t.Fatalf("token within TTL = %s, want cached %s", cached, first)
+	}
+	clock.Advance(time.Second)
+	renewed, err := client.Tokens().Token(ctx, openId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed == first || loginCount(srv) != 2 {
+		t.Fatalf("token after TTL = %s after %d logins, want new token", renewed, loginCount(srv))
+	}
+}
+
+func TestTokenManagerSingleFlight(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	entered, release := blockLogin(client)
+
+	const callers = 8
+	var wg sync.WaitGroup
+	tokens := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], errs[i] = client.Tokens().Token(context.Background(), openId)
+		}(i)
+	}
+	<-entered
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range tokens {
+		if errs[i] != nil || tokens[i] != tokens[0] {
+			t.Fatalf("caller %d: token %s, err %v; want %s", i, tokens[i], errs[i], tokens[0])
+		}
+	}
+	if n := loginCount(srv); n != 1 {
+		t.Fatalf("logins = %d, want 1", n)
+	}
+}
+
+func TestTokenManagerCanceledLeader(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	entered, release := blockLogin(client)
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.Tokens().Token(ctx, openId)
+		leaderErr <- err
+	}()
+	<-entered
+
+	follower := make(chan error, 1)
+	var token string
+	go func() {
+		var err error
+		token, err = client.Tokens().Token(context.Background(), openId)
+		follower <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("leader err = %v, want context.Canceled", err)
+	}
+	// 发起登录的调用者被取消，不影响仍有效的调用者
+	if err := <-follower; err != nil || token == "" {
+		t.Fatalf("follower token %q, err %v; want token", token, err)
+	}
+}
+
+func TestByOpenIdLoginFailureResult(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+
+	srv.FailNext(junyousdk.APIPathAuthLogin, http.StatusServiceUnavailable)
+	pre, err := client.API().PreRewardGOCByOpenId(junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("1")}, openId)
+	var apiErr *junyousdk.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want login APIError 503", err)
+	}
+	if pre == nil || pre.Success || pre.Code != http.StatusServiceUnavailable {
+		t.Fatalf("result = %+v, want failed result with code 503", pre)
+	}
+
+	page, err := client.API().GetEWTBalancePageByOpenId(1, 10, " ")
+	if err == nil || page == nil || page.Success || page.Code != http.StatusBadRequest {
+		t.Fatalf("GetEWTBalancePageByOpenId(blank) = %+v, %v; want param error result", page, err)
+	}
+}
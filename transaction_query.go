@@ -0,0 +1,192 @@
+package junyousdk
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TransactionType 权证交易类型
+type TransactionType string
+
+const (
+	// TransactionTypeIn 转入
+	TransactionTypeIn TransactionType = "in"
+	// TransactionTypeOut 转出
+	TransactionTypeOut TransactionType = "out"
+)
+
+// TransactionQuery 权证交易明细查询条件，由 NewTransactionQuery 创建后链式设置，交给 APIService.QueryEWTTransactions 执行。
+// 服务端只支持按单个年/月筛选，设置日期范围时 SDK 会按月拆分请求并合并结果。
+type TransactionQuery struct {
+	transactionType TransactionType
+	bizType         string
+	from, to        time.Time
+	year, month     int
+	pageSize        int
+	openAuth        string
+	openId          string
+}
+
+// NewTransactionQuery 创建不带筛选条件的查询（企业维度、全部时间）
+func NewTransactionQuery() *TransactionQuery {
+	return &TransactionQuery{}
+}
+
+// WithTransactionType 按交易类型筛选
+func (q *TransactionQuery) WithTransactionType(transactionType TransactionType) *TransactionQuery {
+	q.transactionType = transactionType
+	return q
+}
+
+// WithBizType 按业务类型筛选
+func (q *TransactionQuery) WithBizType(bizType string) *TransactionQuery {
+	q.bizType = bizType
+	return q
+}
+
+// WithDateRange 按创建时间筛选，范围为 [from, to)，可跨月、跨年；按 from 的时区拆分年月（应与服务端按年月筛选所用的时区一致），
+// 不带时区的 created_at 也按该时区解析。与 WithMonth 互斥，后设置的生效
+func (q *TransactionQuery) WithDateRange(from, to time.Time) *TransactionQuery {
+	q.from, q.to = from, to
+	q.year, q.month = 0, 0
+	return q
+}
+
+// WithMonth 按服务端的年月筛选，等同 GetEWTTransactionDetails 的 year、month；与 WithDateRange 互斥，后设置的生效
+func (q *TransactionQuery) WithMonth(year int, month time.Month) *TransactionQuery {
+	q.year, q.month = year, int(month)
+	q.from, q.to = time.Time{}, time.Time{}
+	return q
+}
+
+// WithPageSize 设置每次请求的条数（可选，默认 DefaultPageSize）
+func (q *TransactionQuery) WithPageSize(pageSize int) *TransactionQuery {
+	q.pageSize = pageSize
+	return q
+}
+
+// WithOpenAuth 按 Open Token 对应用户维度查询
+func (q *TransactionQuery) WithOpenAuth(openAuth string) *TransactionQuery {
+	q.openAuth = openAuth
+	q.openId = ""
+	return q
+}
+
+// WithOpenId 按 openId 用户维度查询，每页请求同 GetEWTTransactionPageByOpenId：Open Token 取自 Client.Tokens 的缓存，
+// 开启 Config.AutoRelogin 时 Token 被拒绝会重新登录并重放
+func (q *TransactionQuery) WithOpenId(openId string) *TransactionQuery {
+	q.openId = openId
+	q.openAuth = ""
+	return q
+}
+
+// QueryEWTTransactions 执行 TransactionQuery，返回全部匹配的交易明细，按 created_at 从新到旧排序。
+// 设置了日期范围时按月逐页查询（每月至少一次请求），并丢弃 created_at 不在范围内的记录；
+// created_at 无法解析的记录保留，排在最后。
+func (s *APIService) QueryEWTTransactions(ctx context.Context, q *TransactionQuery) ([]EWTTransaction, error) {
+	if q == nil {
+		q = NewTransactionQuery()
+	}
+	hasRange := !q.from.IsZero() || !q.to.IsZero()
+	if hasRange && (q.from.IsZero() || q.to.IsZero()) {
+		return nil, errors.New("transaction query date range needs both from and to")
+	}
+	if hasRange && !q.from.Before(q.to) {
+		return nil, errors.New("transaction query date range is empty: from must be before to")
+	}
+
+	filter := EWTTransactionFilter{
+		TransactionType: string(q.transactionType),
+		BizType:         q.bizType,
+		Year:            q.year,
+		Month:           q.month,
+	}
+	loc := time.Local
+	if hasRange {
+		loc = q.from.Location()
+	}
+	each := func(filter EWTTransactionFilter, fn func(EWTTransaction) error) error {
+		if q.openId == "" {
+			return s.EachEWTTransaction(ctx, filter, q.pageSize, q.openAuth, fn)
+		}
+		return s.eachEWTTransaction(ctx, q.pageSize, func(page, pageSize int) (*Result[EWTTransactionPage], error) {
+			return s.GetEWTTransactionPageByOpenIdContext(ctx,
+				page, pageSize,
+				filter.TransactionType, filter.BizType,
+				filter.Year, filter.Month,
+				q.openId,
+			)
+		}, fn)
+	}
+	var items []EWTTransaction
+	collect := func(tx EWTTransaction) error {
+		if hasRange {
+			if t, ok := parseTransactionTime(tx.CreatedAt, loc); ok && (t.Before(q.from) || !t.Before(q.to)) {
+				return nil
+			}
+		}
+		items = append(items, tx)
+		return nil
+	}
+
+	if !hasRange {
+		if err := each(filter, collect); err != nil {
+			return nil, err
+		}
+	} else {
+		from := q.from
+		last := q.to.Add(-time.Nanosecond).In(from.Location())
+		for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()); !month.After(last); month = month.AddDate(0, 1, 0) {
+			filter.Year, filter.Month = month.Year(), int(month.Month())
+			if err := each(filter, collect); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sortTransactionsDesc(items, loc)
+	return items, nil
+}
+
+// sortTransactionsDesc 按 created_at 从新到旧稳定排序，无法解析的排在最后
+func sortTransactionsDesc(items []EWTTransaction, loc *time.Location) {
+	times := make(map[string]time.Time, len(items))
+	for _, tx := range items {
+		if t, ok := parseTransactionTime(tx.CreatedAt, loc); ok {
+			times[tx.CreatedAt] = t
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		ti, iok := times[items[i].CreatedAt]
+		tj, jok := times[items[j].CreatedAt]
+		if iok != jok {
+			return iok
+		}
+		return iok && ti.After(tj)
+	})
+}
+
+// transactionTimeLayouts created_at 支持的格式
+var transactionTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseTransactionTime 解析交易明细的 created_at；不带时区的按 loc 解析
+func parseTransactionTime(s string, loc *time.Location) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range transactionTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,46 @@
+package junyousdk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTransactionTime(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"2026-03-01T10:00:00Z", time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), true},
+		{"2026-03-01T10:00:00.5+08:00", time.Date(2026, 3, 1, 10, 0, 0, 5e8, loc), true},
+		{"2026-03-01 10:00:00", time.Date(2026, 3, 1, 10, 0, 0, 0, loc), true},
+		{"2026-03-01T10:00:00", time.Date(2026, 3, 1, 10, 0, 0, 0, loc), true},
+		{" 2026-03-01 ", time.Date(2026, 3, 1, 0, 0, 0, 0, loc), true},
+		{"", time.Time{}, false},
+		{"03/01/2026", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseTransactionTime(tt.in, loc)
+		if ok != tt.ok || (ok && !got.Equal(tt.want)) {
+			t.Errorf("parseTransactionTime(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSortTransactionsDesc(t *testing.T) {
+	items := []EWTTransaction{
+		{BizNo: "bad1", CreatedAt: "unknown"},
+		{BizNo: "old", CreatedAt: "2026-01-01 00:00:00"},
+		{BizNo: "new", CreatedAt: "2026-03-01T00:00:00Z"},
+		{BizNo: "bad2", CreatedAt: ""},
+		{BizNo: "mid", CreatedAt: "2026-02-01"},
+	}
+	sortTransactionsDesc(items, time.UTC)
+	want := []string{"new", "mid", "old", "bad1", "bad2"}
+	for i, tx := range items {
+		if tx.BizNo != want[i] {
+			t.Fatalf("order = %+v, want %v", items, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package junyousdk_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+// queryServer 返回带有 2025-10 至 2026-03 每月 10 日、20 日各一条流水（biz_no 为 yyyy-mm-dd）的模拟服务与客户端
+func queryServer(t *testing.T) (*junyoutest.Server, *junyousdk.Client, string) {
+	t.Helper()
+	srv := junyoutest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	other := srv.AddUser("13800138001")
+	for month := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC); month.Year() < 2026 || month.Month() <= 3; month = month.AddDate(0, 1, 0) {
+		for _, day := range []int{10, 20} {
+			at := month.AddDate(0, 0, day-1)
+			txType := "in"
+			if day == 20 {
+				txType = "out"
+			}
+			for _, id := range []string{openId, other} {
+				if err := srv.AddTransaction(junyoutest.Transaction{
+					BizNo:           at.Format("2006-01-02"),
+					OpenId:          id,
+					TransactionType: txType,
+					BizType:         junyoutest.BizTypeEWTReleaseByPartner,
+					Amount:          "1",
+					CreatedAt:       at.Format(time.RFC3339),
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	return srv, client, openId
+}
+
+// queriedMonths 返回交易明细请求的 year-month 序列
+func queriedMonths(srv *junyoutest.Server) []string {
+	var months []string
+	for _, r := range srv.Requests() {
+		if !strings.HasPrefix(r.Path, junyousdk.APIPathEWTTransactionDetails) {
+			continue
+		}
+		u, err := url.Parse(r.Path)
+		if err != nil {
+			continue
+		}
+		months = append(months, u.Query().Get("year")+"-"+u.Query().Get("month"))
+	}
+	return months
+}
+
+func bizNos(items []junyousdk.EWTTransaction) string {
+	nos := make([]string, len(items))
+	for i, tx := range items {
+		nos[i] = tx.BizNo
+	}
+	return strings.Join(nos, ",")
+}
+
+func TestQueryEWTTransactionsDateRange(t *testing.T) {
+	srv, client, openId := queryServer(t)
+
+	q := junyousdk.NewTransactionQuery().
+		WithDateRange(
+			time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC),
+		).
+		WithOpenId(openId).
+		WithPageSize(1)
+	items, err := client.API().QueryEWTTransactions(context.Background(), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 范围为 [from, to)：不含 11-10 与 02-20
+	want := "2026-02-10,2026-01-20,2026-01-10,2025-12-20,2025-12-10,2025-11-20"
+	if got := bizNos(items); got != want {
+		t.Fatalf("biz_nos = %s, want %s", got, want)
+	}
+
+	months := map[string]bool{}
+	for _, m := range queriedMonths(srv) {
+		months[m] = true
+	}
+	if len(months) != 4 || !months["2025-11"] || !months["2025-12"] || !months["2026-1"] || !months["2026-2"] {
+		t.Fatalf("queried months = %v, want 2025-11 to 2026-2", months)
+	}
+}
+
+func TestQueryEWTTransactionsMonthAndFilters(t *testing.T) {
+	srv, client, _ := queryServer(t)
+
+	q := junyousdk.NewTransactionQuery().
+		WithDateRange(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)).
+		WithMonth(2026, time.March).
+		WithTransactionType(junyousdk.TransactionTypeOut).
+		WithBizType(junyoutest.BizTypeEWTReleaseByPartner)
+	items, err := client.API().QueryEWTTransactions(context.Background(), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 企业维度包含两个用户的流水
+	if got := bizNos(items); got != "2026-03-20,2026-03-20" {
+		t.Fatalf("biz_nos = %s, want two 2026-03-20", got)
+	}
+	if months := queriedMonths(srv); len(months) != 1 || months[0] != "2026-3" {
+		t.Fatalf("queried months = %v, want [2026-3]", months)
+	}
+}
+
+func TestQueryEWTTransactionsInvalidRange(t *testing.T) {
+	srv, client, _ := queryServer(t)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{"missing to", from, time.Time{}},
+		{"missing from", time.Time{}, from},
+		{"empty", from, from},
+		{"reversed", from, from.AddDate(0, -1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := junyousdk.NewTransactionQuery().WithDateRange(tt.from, tt.to)
+			if _, err := client.API().QueryEWTTransactions(context.Background(), q); err == nil {
+				t.Fatal("invalid range accepted")
+			}
+		})
+	}
+	if months := queriedMonths(srv); len(months) != 0 {
+		t.Fatalf("queried months = %v, want none", months)
+	}
+}
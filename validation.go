@@ -0,0 +1,148 @@
+package junyousdk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	// Field JSON 字段名，如 level1_ratio
+	Field string
+	// Message 错误说明
+	Message string
+	// Err 对应的已知错误（如 ErrInvalidAmount、ErrInvalidRatio、ErrInvalidOpenId），可为 nil
+	Err error
+}
+
+// Error 实现 error 接口
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError 请求参数校验错误，列出全部不合法的字段；
+// 可用 errors.As 取得字段列表，或用 errors.Is 与 ErrInvalidAmount 等比较
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error 实现 error 接口
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap 返回各字段的已知错误
+func (e *ValidationError) Unwrap() []error {
+	var errs []error
+	for _, f := range e.Fields {
+		if f.Err != nil {
+			errs = append(errs, f.Err)
+		}
+	}
+	return errs
+}
+
+// ErrInvalidOpenId OpenId 无效（仅含空白）
+var ErrInvalidOpenId = errors.New("junyousdk: invalid open_id")
+
+// validator 收集字段错误
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) add(field string, err error, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...), Err: err})
+}
+
+// positiveAmount 检查金额已设置且大于 0
+func (v *validator) positiveAmount(field string, a Amount) {
+	if a.Sign() <= 0 {
+		v.add(field, ErrInvalidAmount, "must be greater than 0, got %q", a.String())
+	}
+}
+
+// ratio 检查比例不为负；required 为 true 时还须已设置且大于 0
+func (v *validator) ratio(field string, r Ratio, required bool) bool {
+	switch {
+	case required && r.Sign() <= 0:
+		v.add(field, ErrInvalidRatio, "must be greater than 0, got %q", r.String())
+	case r.Sign() < 0:
+		v.add(field, ErrInvalidRatio, "must not be negative, got %q", r.String())
+	default:
+		return true
+	}
+	return false
+}
+
+// openId 检查 OpenId 不为空白；接口文档未规定 OpenId 格式，不做其他检查
+func (v *validator) openId(field, openId string) {
+	if strings.TrimSpace(openId) == "" {
+		v.add(field, ErrInvalidOpenId, "must not be blank, got %q", openId)
+	}
+}
+
+// err 无字段错误时返回 nil
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+// Validate 校验 GOC 预提交请求：amount 须大于 0。PreRewardGOC 及其变体发送前自动调用
+func (r PreGOCRewardRequest) Validate() error {
+	var v validator
+	v.positiveAmount("amount", r.Amount)
+	return v.err()
+}
+
+// Validate 校验权证合伙人释放预提交请求，返回 *ValidationError 列出全部问题：
+//   - amount 大于 0，ratio 大于 0，level1_ratio、level2_ratio 不为负（比例单位以服务端为准，不限定上限）；
+//   - 设置了某级比例（非 0）就须设置该级 OpenId；
+//   - 有二级合伙人时须有一级合伙人，且两级不能是同一用户；
+//   - 设置的 OpenId 不能只含空白；
+//   - level1_ratio + level2_ratio 不超过 ratio。
+//
+// PreCommitEWTReleaseByPartner 及其变体、ReleaseEWTByPartner 发送前自动调用。
+func (r PreEWTReleaseByPartnerRequest) Validate() error {
+	var v validator
+	v.positiveAmount("amount", r.Amount)
+	ratioOK := v.ratio("ratio", r.Ratio, true)
+	level1OK := v.ratio("level1_ratio", r.Level1Ratio, false)
+	level2OK := v.ratio("level2_ratio", r.Level2Ratio, false)
+
+	levels := []struct {
+		openIdField, ratioField string
+		openId                  string
+		ratio                   Ratio
+	}{
+		{"level1_open_id", "level1_ratio", r.Level1OpenId, r.Level1Ratio},
+		{"level2_open_id", "level2_ratio", r.Level2OpenId, r.Level2Ratio},
+	}
+	for _, level := range levels {
+		switch {
+		case level.openId == "" && !level.ratio.IsZero():
+			v.add(level.openIdField, nil, "is required when %s is set", level.ratioField)
+		case level.openId != "":
+			v.openId(level.openIdField, level.openId)
+		}
+	}
+	if r.Level2OpenId != "" && r.Level1OpenId == "" {
+		v.add("level1_open_id", nil, "is required when level2_open_id is set")
+	}
+	if r.Level2OpenId != "" && strings.EqualFold(r.Level1OpenId, r.Level2OpenId) {
+		v.add("level2_open_id", nil, "must differ from level1_open_id")
+	}
+
+	if ratioOK && level1OK && level2OK {
+		if sum := r.Level1Ratio.Add(r.Level2Ratio); sum.Cmp(r.Ratio) > 0 {
+			v.add("level1_ratio", ErrInvalidRatio, "level1_ratio + level2_ratio (%s) must not exceed ratio (%s)", sum.String(), r.Ratio.String())
+		}
+	}
+	return v.err()
+}
@@ -0,0 +1,124 @@
+package junyousdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+	"github.com/junyouava/junyou-sdk-go/junyoutest"
+)
+
+func TestPreEWTReleaseByPartnerRequestValidate(t *testing.T) {
+	level1, level2 := "level1-user", "level2-user"
+	tests := []struct {
+		name   string
+		req    junyousdk.PreEWTReleaseByPartnerRequest
+		fields []string
+	}{
+		{"ratio above one", junyousdk.PreEWTReleaseByPartnerRequest{
+			Amount: junyousdk.MustParseAmount("100"), Ratio: junyousdk.MustParseRatio("10"),
+			Level1OpenId: level1, Level1Ratio: junyousdk.MustParseRatio("3"),
+			Level2OpenId: level2, Level2Ratio: junyousdk.MustParseRatio("2"),
+		}, nil},
+		{"missing amount and ratio", junyousdk.PreEWTReleaseByPartnerRequest{}, []string{"amount", "ratio"}},
+		{"levels exceed ratio", junyousdk.PreEWTReleaseByPartnerRequest{
+			Amount: junyousdk.MustParseAmount("100"), Ratio: junyousdk.MustParseRatio("10"),
+			Level1OpenId: level1, Level1Ratio: junyousdk.MustParseRatio("6"),
+			Level2OpenId: level2, Level2Ratio: junyousdk.MustParseRatio("5"),
+		}, []string{"level1_ratio"}},
+		{"ratio without open_id", junyousdk.PreEWTReleaseByPartnerRequest{
+			Amount: junyousdk.MustParseAmount("1"), Ratio: junyousdk.MustParseRatio("1"),
+			Level1Ratio: junyousdk.MustParseRatio("0.1"),
+		}, []string{"level1_open_id"}},
+		{"level2 without level1", junyousdk.PreEWTReleaseByPartnerRequest{
+			Amount: junyousdk.MustParseAmount("1"), Ratio: junyousdk.MustParseRatio("1"),
+			Level2OpenId: level2, Level2Ratio: junyousdk.MustParseRatio("0.1"),
+		}, []string{"level1_open_id"}},
+		{"open_id without ratio", junyousdk.PreEWTReleaseByPartnerRequest{
+			Amount: junyousdk.MustParseAmount("1"), Ratio: junyousdk.MustParseRatio("1"),
+			Level1OpenId: level1,
+		}, nil},
+		{"blank open_id", junyousdk.PreEWTReleaseByPartnerRequest{
+			Amount: junyousdk.MustParseAmount("1"), Ratio: junyousdk.MustParseRatio("1"),
+			Level1OpenId: "  ", Level1Ratio: junyousdk.MustParseRatio("0.1"),
+		}, []string{"level1_open_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			var verr *junyousdk.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate = %v, want *ValidationError", err)
+			}
+			var got []string
+			for _, f := range verr.Fields {
+				got = append(got, f.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.fields, ",") {
+				t.Fatalf("fields = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
+
+func TestPreGOCRewardRequestValidate(t *testing.T) {
+	if err := (junyousdk.PreGOCRewardRequest{Amount: junyousdk.MustParseAmount("0.01")}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := (junyousdk.PreGOCRewardRequest{}).Validate(); !errors.Is(err, junyousdk.ErrInvalidAmount) {
+		t.Fatalf("err = %v, want ErrInvalidAmount", err)
+	}
+}
+
+func TestPreSubmitValidationResult(t *testing.T) {
+	srv := junyoutest.NewServer()
+	defer srv.Close()
+	client, err := junyousdk.NewClient(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	openId := srv.AddUser("13800138000")
+	ctx := context.Background()
+
+	check := func(name string, success bool, code int, err error) {
+		t.Helper()
+		var verr *junyousdk.ValidationError
+		if success || code != http.StatusBadRequest || !errors.As(err, &verr) {
+			t.Errorf("%s: success %v, code %d, err %v; want param error result with *ValidationError", name, success, code, err)
+		}
+	}
+	gocReq := junyousdk.PreGOCRewardRequest{}
+	if r, err := client.API().PreRewardGOCContext(ctx, gocReq, "token"); r != nil {
+		check("PreRewardGOC", r.Success, r.Code, err)
+	} else {
+		t.Error("PreRewardGOC returned nil result")
+	}
+	if r, err := client.API().PreRewardGOCMessageByOpenIdContext(ctx, gocReq, openId); r != nil {
+		check("PreRewardGOCMessageByOpenId", r.Success, r.Code, err)
+	} else {
+		t.Error("PreRewardGOCMessageByOpenId returned nil result")
+	}
+	ewtReq := junyousdk.PreEWTReleaseByPartnerRequest{Amount: junyousdk.MustParseAmount("1")}
+	if r, err := client.API().PreCommitEWTReleaseByPartnerMessageContext(ctx, ewtReq, "token"); r != nil {
+		check("PreCommitEWTReleaseByPartnerMessage", r.Success, r.Code, err)
+	} else {
+		t.Error("PreCommitEWTReleaseByPartnerMessage returned nil result")
+	}
+	if r, err := client.API().PreCommitEWTReleaseByPartnerByOpenIdContext(ctx, ewtReq, openId); r != nil {
+		check("PreCommitEWTReleaseByPartnerByOpenId", r.Success, r.Code, err)
+	} else {
+		t.Error("PreCommitEWTReleaseByPartnerByOpenId returned nil result")
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want none", n)
+	}
+}
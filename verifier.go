@@ -0,0 +1,285 @@
+package junyousdk
+
+import (
+	"container/heap"
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 验签错误
+var (
+	// ErrMissingSignatureHeader 缺少签名 Header
+	ErrMissingSignatureHeader = errors.New("junyousdk: missing signature header")
+	// ErrUnknownAccessId 未知的 AccessId
+	ErrUnknownAccessId = errors.New("junyousdk: unknown access_id")
+	// ErrTimestampInvalid 时间戳格式错误、已过期或超出有效期窗口
+	ErrTimestampInvalid = errors.New("junyousdk: timestamp invalid or expired")
+	// ErrSignatureMismatch 签名不匹配
+	ErrSignatureMismatch = errors.New("junyousdk: signature mismatch")
+	// ErrNonceReplayed nonce 重复使用
+	ErrNonceReplayed = errors.New("junyousdk: nonce replayed")
+)
+
+// KeyStore 按 AccessId 查找 AccessKey（Base64 编码）
+type KeyStore interface {
+	// AccessKey 返回 accessId 对应的 AccessKey；不存在时返回 ErrUnknownAccessId
+	AccessKey(ctx context.Context, accessId string) (string, error)
+}
+
+// KeyStoreFunc 函数形式的 KeyStore
+type KeyStoreFunc func(ctx context.Context, accessId string) (string, error)
+
+// AccessKey 实现 KeyStore
+func (f KeyStoreFunc) AccessKey(ctx context.Context, accessId string) (string, error) {
+	return f(ctx, accessId)
+}
+
+// StaticKeyStore 固定的 AccessId -> AccessKey 映射
+type StaticKeyStore map[string]string
+
+// AccessKey 实现 KeyStore
+func (s StaticKeyStore) AccessKey(_ context.Context, accessId string) (string, error) {
+	key, ok := s[accessId]
+	if !ok {
+		return "", ErrUnknownAccessId
+	}
+	return key, nil
+}
+
+// NonceStore 记录已使用的 nonce，用于拒绝重放请求
+type NonceStore interface {
+	// Remember 记录 accessId 下的 nonce，保留到 expiresAt；nonce 已存在时返回 false
+	Remember(ctx context.Context, accessId, nonce string, expiresAt time.Time) (bool, error)
+}
+
+// MemoryNonceStore 进程内 NonceStore。过期条目按过期时间放入最小堆，写入时只弹出堆顶已过期的条目，
+// 每次写入的清理开销为 O(log n)，不随已记录的 nonce 数量线性增长
+type MemoryNonceStore struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	expiry  nonceHeap
+	clock   Clock
+}
+
+// nonceEntry 堆中的 nonce 条目
+type nonceEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// nonceHeap 按过期时间排序的最小堆，实现 heap.Interface
+type nonceHeap []nonceEntry
+
+func (h nonceHeap) Len() int           { return len(h) }
+func (h nonceHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h nonceHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nonceHeap) Push(x any)        { *h = append(*h, x.(nonceEntry)) }
+func (h *nonceHeap) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	*h = old[:n-1]
+	return e
+}
+
+// NewMemoryNonceStore 创建进程内 NonceStore
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return NewMemoryNonceStoreWithClock(SystemClock)
+}
+
+// NewMemoryNonceStoreWithClock 使用指定时钟创建进程内 NonceStore
+func NewMemoryNonceStoreWithClock(clock Clock) *MemoryNonceStore {
+	return &MemoryNonceStore{
+		entries: map[string]time.Time{},
+		clock:   clock,
+	}
+}
+
+// Remember 实现 NonceStore
+func (s *MemoryNonceStore) Remember(_ context.Context, accessId, nonce string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	for len(s.expiry) > 0 && s.expiry[0].expiresAt.Before(now) {
+		e := heap.Pop(&s.expiry).(nonceEntry)
+		delete(s.entries, e.key)
+	}
+
+	key := accessId + "\n" + nonce
+	if _, ok := s.entries[key]; ok {
+		return false, nil
+	}
+	s.entries[key] = expiresAt
+	heap.Push(&s.expiry, nonceEntry{key: key, expiresAt: expiresAt})
+	return true, nil
+}
+
+// Verifier 签名校验器，是 AuthService.GenerateSignature 的逆过程：
+// 按 X-Access-ID 查找 AccessKey，重算 accessId\nMETHOD\npath\nnonce\ntimestamp 的 HMAC-SHA256 并做常量时间比较，
+// path 不含 query；X-Timestamp 为签名过期时间，须未过期且不超过 now + MaxValidity。
+type Verifier struct {
+	keys        KeyStore
+	nonces      NonceStore
+	maxValidity time.Duration
+	clockSkew   time.Duration
+	clock       Clock
+	logger      *slog.Logger
+}
+
+// NewVerifier 创建签名校验器；默认有效期窗口为 DefaultSignatureValidity，允许 30 秒时钟偏差，不做 nonce 防重放
+func NewVerifier(keys KeyStore) *Verifier {
+	return &Verifier{
+		keys:        keys,
+		maxValidity: DefaultSignatureValidity,
+		clockSkew:   30 * time.Second,
+		clock:       SystemClock,
+	}
+}
+
+// WithNonceStore 设置 nonce 存储，开启防重放
+func (v *Verifier) WithNonceStore(store NonceStore) *Verifier {
+	v.nonces = store
+	return v
+}
+
+// WithMaxValidity 设置签名有效期窗口：X-Timestamp 不得晚于 now + maxValidity
+func (v *Verifier) WithMaxValidity(maxValidity time.Duration) *Verifier {
+	v.maxValidity = maxValidity
+	return v
+}
+
+// WithClockSkew 设置允许的时钟偏差
+func (v *Verifier) WithClockSkew(clockSkew time.Duration) *Verifier {
+	v.clockSkew = clockSkew
+	return v
+}
+
+// WithClock 设置时钟，用于判断时间戳是否过期
+func (v *Verifier) WithClock(clock Clock) *Verifier {
+	v.clock = clock
+	return v
+}
+
+// WithLogger 设置 Middleware 记录验签失败原因的日志记录器；不设置时使用 slog.Default()
+func (v *Verifier) WithLogger(logger *slog.Logger) *Verifier {
+	v.logger = logger
+	return v
+}
+
+// Verify 校验签名 Header，成功时返回 AccessId。apiPath 可含 query，参与验签的为 ? 前的 path。
+func (v *Verifier) Verify(ctx context.Context, method, apiPath string, header http.Header) (string, error) {
+	accessId := headerValue(header, HeaderAccessId)
+	signature := headerValue(header, HeaderSignature)
+	nonce := headerValue(header, HeaderNonce)
+	timestamp := headerValue(header, HeaderTimestamp)
+	if accessId == "" || signature == "" || nonce == "" || timestamp == "" {
+		return "", ErrMissingSignatureHeader
+	}
+
+	// 校验时间戳窗口
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrTimestampInvalid, timestamp)
+	}
+	expiresAt := time.Unix(unix, 0)
+	now := v.clock.Now()
+	if expiresAt.Before(now.Add(-v.clockSkew)) {
+		return "", fmt.Errorf("%w: expired at %s", ErrTimestampInvalid, expiresAt.Format(time.RFC3339))
+	}
+	if expiresAt.After(now.Add(v.maxValidity + v.clockSkew)) {
+		return "", fmt.Errorf("%w: too far in the future", ErrTimestampInvalid)
+	}
+
+	// 查找 AccessKey 并重算签名
+	accessKey, err := v.keys.AccessKey(ctx, accessId)
+	if err != nil {
+		return "", err
+	}
+	expected, err := computeSignature(accessKey, accessId, method, apiPath, nonce, timestamp)
+	if err != nil {
+		return "", err
+	}
+	expectedBytes, _ := base64.StdEncoding.DecodeString(expected)
+	actualBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expectedBytes, actualBytes) {
+		return "", ErrSignatureMismatch
+	}
+
+	// 签名通过后再记录 nonce，避免伪造请求占用 nonce
+	if v.nonces != nil {
+		fresh, err := v.nonces.Remember(ctx, accessId, nonce, expiresAt.Add(v.clockSkew))
+		if err != nil {
+			return "", fmt.Errorf("failed to store nonce: %w", err)
+		}
+		if !fresh {
+			return "", ErrNonceReplayed
+		}
+	}
+
+	return accessId, nil
+}
+
+// headerValue 读取 Header 值；兼容 GenerateAuthHeader 生成的非规范化键名（如 X-Access-ID）
+func headerValue(header http.Header, key string) string {
+	if v := header.Get(key); v != "" {
+		return v
+	}
+	if v := header[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// VerifyRequest 校验 HTTP 请求的签名 Header，成功时返回 AccessId
+func (v *Verifier) VerifyRequest(r *http.Request) (string, error) {
+	return v.Verify(r.Context(), r.Method, r.URL.EscapedPath(), r.Header)
+}
+
+// accessIdContextKey context 中 AccessId 的 key
+type accessIdContextKey struct{}
+
+// AccessIdFromContext 返回 Verifier.Middleware 写入 context 的 AccessId
+func AccessIdFromContext(ctx context.Context) (string, bool) {
+	accessId, ok := ctx.Value(accessIdContextKey{}).(string)
+	return accessId, ok
+}
+
+// Middleware 返回 net/http 中间件：验签失败时返回 401 及 Result 格式的 JSON，成功时将 AccessId 写入请求 context。
+// 401 响应只包含通用消息，不透露失败原因（如 AccessId 是否存在）；具体原因按 Warn 级别写入日志（见 WithLogger）。
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accessId, err := v.VerifyRequest(r)
+		if err != nil {
+			logger := v.logger
+			if logger == nil {
+				logger = slog.Default()
+			}
+			logger.LogAttrs(r.Context(), slog.LevelWarn, "junyousdk signature verification failed",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("access_id", headerValue(r.Header, HeaderAccessId)),
+				slog.String("error", err.Error()),
+			)
+			result := newResult[any](http.StatusUnauthorized, false, unauthorizedMessage, nil)
+			w.Header().Set(HeaderContentType, DefaultContentType)
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(result)
+			return
+		}
+		ctx := context.WithValue(r.Context(), accessIdContextKey{}, accessId)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// unauthorizedMessage Middleware 验签失败时返回的通用消息
+const unauthorizedMessage = "unauthorized"
@@ -0,0 +1,44 @@
+package junyousdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemoryNonceStoreSweepsExpired(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryNonceStoreWithClock(ClockFunc(func() time.Time { return now }))
+	ctx := context.Background()
+
+	for i := 0; i < 100; i++ {
+		ok, err := store.Remember(ctx, "id", fmt.Sprintf("n%d", i), now.Add(time.Duration(i+1)*time.Second))
+		if err != nil || !ok {
+			t.Fatalf("Remember(n%d) = %v, %v", i, ok, err)
+		}
+	}
+	if ok, _ := store.Remember(ctx, "id", "n5", now.Add(time.Minute)); ok {
+		t.Fatal("Remember accepted a replayed nonce")
+	}
+	if ok, _ := store.Remember(ctx, "other", "n5", now.Add(time.Minute)); !ok {
+		t.Fatal("Remember rejected the same nonce under another access_id")
+	}
+
+	// 前 50 个条目过期，下一次写入时从堆顶清理
+	now = now.Add(50*time.Second + time.Millisecond)
+	if ok, _ := store.Remember(ctx, "id", "fresh", now.Add(time.Minute)); !ok {
+		t.Fatal("Remember(fresh) = false")
+	}
+	if got, want := len(store.entries), 52; got != want {
+		t.Fatalf("entries = %d, want %d", got, want)
+	}
+	if len(store.expiry) != len(store.entries) {
+		t.Fatalf("heap size %d != entries %d", len(store.expiry), len(store.entries))
+	}
+
+	// 过期的 nonce 被清理后可以再次使用
+	if ok, _ := store.Remember(ctx, "id", "n5", now.Add(time.Minute)); !ok {
+		t.Fatal("Remember rejected an expired nonce")
+	}
+}
@@ -0,0 +1,124 @@
+package junyousdk_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	junyousdk "github.com/junyouava/junyou-sdk-go"
+)
+
+const (
+	testAccessId  = "test-access-id"
+	testAccessKey = "dGVzdC1hY2Nlc3Mta2V5" // base64("test-access-key")
+)
+
+func newSigningClient(t *testing.T, accessId string, clock junyousdk.Clock) *junyousdk.Client {
+	t.Helper()
+	client, err := junyousdk.NewClient(junyousdk.DefaultConfig().
+		WithAccessId(accessId).
+		WithAccessKey(testAccessKey).
+		WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestVerifier(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := junyousdk.ClockFunc(func() time.Time { return now })
+	verifier := junyousdk.NewVerifier(junyousdk.StaticKeyStore{testAccessId: testAccessKey}).
+		WithNonceStore(junyousdk.NewMemoryNonceStoreWithClock(clock)).
+		WithClock(clock)
+	client := newSigningClient(t, testAccessId, clock)
+	ctx := context.Background()
+
+	header, err := client.Auth().GenerateAuthHeader(http.MethodGet, "/api/x?page=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	accessId, err := verifier.Verify(ctx, http.MethodGet, "/api/x?page=2", header)
+	if err != nil || accessId != testAccessId {
+		t.Fatalf("Verify = %q, %v", accessId, err)
+	}
+	if _, err := verifier.Verify(ctx, http.MethodGet, "/api/x", header); !errors.Is(err, junyousdk.ErrNonceReplayed) {
+		t.Fatalf("replay: err = %v, want ErrNonceReplayed", err)
+	}
+
+	header, _ = client.Auth().GenerateAuthHeader(http.MethodGet, "/api/x")
+	if _, err := verifier.Verify(ctx, http.MethodPost, "/api/x", header); !errors.Is(err, junyousdk.ErrSignatureMismatch) {
+		t.Fatalf("wrong method: err = %v, want ErrSignatureMismatch", err)
+	}
+
+	now = now.Add(10 * time.Minute)
+	if _, err := verifier.Verify(ctx, http.MethodGet, "/api/x", header); !errors.Is(err, junyousdk.ErrTimestampInvalid) {
+		t.Fatalf("expired: err = %v, want ErrTimestampInvalid", err)
+	}
+
+	unknown := newSigningClient(t, "unknown", clock)
+	header, _ = unknown.Auth().GenerateAuthHeader(http.MethodGet, "/api/x")
+	if _, err := verifier.Verify(ctx, http.MethodGet, "/api/x", header); !errors.Is(err, junyousdk.ErrUnknownAccessId) {
+		t.Fatalf("unknown access id: err = %v, want ErrUnknownAccessId", err)
+	}
+	if _, err := verifier.Verify(ctx, http.MethodGet, "/api/x", http.Header{}); !errors.Is(err, junyousdk.ErrMissingSignatureHeader) {
+		t.Fatalf("no header: err = %v, want ErrMissingSignatureHeader", err)
+	}
+}
+
+func TestVerifierMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	verifier := junyousdk.NewVerifier(junyousdk.StaticKeyStore{testAccessId: testAccessKey}).
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	handler := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accessId, _ := junyousdk.AccessIdFromContext(r.Context())
+		io.WriteString(w, accessId)
+	}))
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	do := func(accessId string) (int, string) {
+		t.Helper()
+		client := newSigningClient(t, accessId, junyousdk.SystemClock)
+		header, err := client.Auth().GenerateAuthHeader(http.MethodGet, "/ping")
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/ping", nil)
+		req.Header = header
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	if status, body := do(testAccessId); status != http.StatusOK || body != testAccessId {
+		t.Fatalf("valid request = %d %q", status, body)
+	}
+
+	status, body := do("unknown-id")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", status)
+	}
+	var result junyousdk.Result[any]
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Message != "unauthorized" || strings.Contains(body, "access_id") || strings.Contains(body, "unknown-id") {
+		t.Fatalf("401 body reveals details: %s", body)
+	}
+	if !strings.Contains(logs.String(), "unknown access_id") || !strings.Contains(logs.String(), "unknown-id") {
+		t.Fatalf("failure detail not logged: %s", logs.String())
+	}
+}